@@ -0,0 +1,192 @@
+//Command exrs serves and maintains the exercise library
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/app"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/transfer"
+)
+
+const usage = `usage: exrs <command> [flags]
+
+commands:
+  serve-grpc     serve the API over gRPC, metrics and checks on a side port
+  serve-gateway  serve the REST gateway in front of a gRPC server
+  serve-all      serve gRPC and the REST gateway on a single port
+  import         create the taxonomy and exercises of an export file
+  export         write the taxonomy and every exercise to a file
+  migrate        migrate exercises stored by older versions, -status lists the migrations
+
+run exrs <command> -h for the flags of a command
+`
+
+//importer is the subject exercises created by import are attributed to
+const importer = "import"
+
+//command runs with the app wired from the parsed config, until ctx is done for servers
+type command struct {
+	//storage tells whether the command needs the database
+	storage bool
+	run     func(ctx context.Context, a *app.App, fs *flag.FlagSet) error
+}
+
+var commands = map[string]command{
+	"serve-grpc":    {true, func(ctx context.Context, a *app.App, _ *flag.FlagSet) error { return a.ServeGRPC(ctx) }},
+	"serve-gateway": {false, func(ctx context.Context, a *app.App, _ *flag.FlagSet) error { return a.ServeGateway(ctx) }},
+	"serve-all":     {true, func(ctx context.Context, a *app.App, _ *flag.FlagSet) error { return a.ServeAll(ctx) }},
+	"import":        {true, importExercises},
+	"export":        {true, exportExercises},
+	"migrate":       {true, migrate},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+	if err := run(name, cmd, os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "exrs %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+func run(name string, cmd command, args []string) error {
+	fs := flag.NewFlagSet("exrs "+name, flag.ExitOnError)
+	cfg := app.DefaultConfig()
+	if name == "import" || name == "export" {
+		fs.String("file", "-", "file to read or write, - for standard input or output")
+	}
+	if name == "migrate" {
+		fs.Int("to", -1, "version to migrate up or down to, the latest when negative")
+		fs.Bool("status", false, "list the migrations and whether they are applied")
+	}
+	if err := cfg.Parse(fs, args); err != nil {
+		return err
+	}
+	a, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
+	// Stop on CTRL+C or when the orchestrator asks us to
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+	go func() {
+		select {
+		case <-c:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if cmd.storage {
+		if err := a.OpenStorage(); err != nil {
+			return err
+		}
+		if name != "migrate" {
+			if err := a.Migrate(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	err = cmd.run(ctx, a, fs)
+	// Close storage last, servers are drained by now
+	closing, stop := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer stop()
+	if cerr := a.Close(closing); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func importExercises(ctx context.Context, a *app.App, fs *flag.FlagSet) error {
+	var r io.Reader = os.Stdin
+	if path := fs.Lookup("file").Value.String(); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	n, err := transfer.Import(auth.NewContext(ctx, auth.Identity{Subject: importer}), a.API, r)
+	fmt.Fprintf(os.Stderr, "imported %d exercises\n", n)
+	return err
+}
+
+func exportExercises(ctx context.Context, a *app.App, fs *flag.FlagSet) error {
+	var w io.Writer = os.Stdout
+	if path := fs.Lookup("file").Value.String(); path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	n, err := transfer.Export(ctx, a.API, w)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d exercises\n", n)
+	return nil
+}
+
+//migrate runs the migrations of the storage up or down to -to, or lists them with -status
+func migrate(ctx context.Context, a *app.App, fs *flag.FlagSet) error {
+	r, err := a.Migrations()
+	if err != nil {
+		return err
+	}
+	if fs.Lookup("status").Value.String() == "true" {
+		status, err := r.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			applied := "pending"
+			if s.Applied != nil {
+				applied = "applied " + s.Applied.Format(time.RFC3339)
+			}
+			fmt.Printf("%4d  %-28s %s\n", s.Version, s.Name, applied)
+		}
+		return nil
+	}
+	to, err := strconv.Atoi(fs.Lookup("to").Value.String())
+	if err != nil {
+		return err
+	}
+	if to < 0 {
+		to = r.Latest()
+	}
+	ran, err := r.To(ctx, to)
+	for _, m := range ran {
+		done := "applied"
+		if m.Version > to {
+			done = "reverted"
+		}
+		fmt.Printf("%s %d %s\n", done, m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("at version %d\n", to)
+	return nil
+}
@@ -0,0 +1,21 @@
+//Command exrsctl administers the exercise catalog through the gRPC API
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/maxvw8/exercise_lib/exrs/cli"
+)
+
+func main() {
+	err := cli.Run(context.Background(), "exrsctl", os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err == cli.ErrUsage {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "exrsctl: %s\n", cli.Describe(err))
+		os.Exit(1)
+	}
+}
@@ -2,11 +2,22 @@ package exrs
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/recommend"
+	"github.com/maxvw8/exercise_lib/exrs/references"
 	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
 	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -16,82 +27,181 @@ import (
 //API asd
 type API struct {
 	storage.ExerciseStorage
+	taxonomy    storage.TaxonomyStorage
+	assets      AssetResolver
+	revisions   storage.RevisionStorage
+	tx          storage.Transactor
+	references  *references.Registry
+	recommender *recommend.Recommender
 }
 
+//Option configures the optional dependencies of the API
+type Option func(*API)
+
 //Server creates a new instance of Exercise API
-func Server(repo storage.ExerciseStorage) (*API, error) {
-	s := &API{repo}
+func Server(repo storage.ExerciseStorage, taxonomy storage.TaxonomyStorage, opts ...Option) (*API, error) {
+	s := &API{ExerciseStorage: repo, taxonomy: taxonomy, tx: storage.NoTransactions{}, references: references.NewRegistry(),
+		recommender: recommend.New(recommend.DefaultEquipment)}
+	for _, opt := range opts {
+		opt(s)
+	}
 	return s, nil
 }
 
 //CreateExercise creates an exercise
 func (s *API) CreateExercise(ctx context.Context, req *pbexrs.CreateExerciseRequest) (*pbexrs.Exercise, error) {
 	log := ctxzap.Extract(ctx).Sugar()
+	if err := s.validateExercise(ctx, req.Exercise); err != nil {
+		log.Debugf("rejected exercise %v. Error was %v", req.Exercise, err)
+		return &pbexrs.Exercise{}, err
+	}
 	e := MarshallExercise(req.Exercise)
+	e.CreateTime, e.CreatedBy = now(), auth.Subject(ctx)
+	e.UpdateTime, e.UpdatedBy = e.CreateTime, e.CreatedBy
 	log.Debugf("creating exercise %v", e)
-	r, err := s.ExerciseStorage.Create(e)
+	var r *storage.Exercise
+	err := s.tx.InTransaction(ctx, func(ctx context.Context) (err error) {
+		if r, err = s.ExerciseStorage.Create(ctx, e); err != nil {
+			return err
+		}
+		return s.record(ctx, storage.RevisionCreate, r.Id, r)
+	})
 	if err != nil {
 		log.Warnf("failed creating new exercise %v. Error was %v", e, err)
 		return &pbexrs.Exercise{}, err
 	}
 	log.Debugf("created exercise %v and error %v", e, err)
-	return UnmarshallExercise(r), err
+	return s.withAssets(ctx, UnmarshallExercise(r)), err
 }
 
 //GetExercise reads an exercise from the repository matching the id
 func (s *API) GetExercise(ctx context.Context, req *pbexrs.GetExerciseRequest) (*pbexrs.Exercise, error) {
 	log := ctxzap.Extract(ctx).Sugar()
 	log.Debugf("getting exercise id %v", req.Id)
-	r, err := s.ExerciseStorage.Read(req.Id)
+	r, err := s.ExerciseStorage.Read(ctx, req.Id)
 	if err != nil {
 		log.Warnf("could not find exercise with id %v. Error was %v", req.Id, err)
 		return &pbexrs.Exercise{}, err
 	}
 	log.Debugf("found exercise %v", r)
-	return UnmarshallExercise(r), err
+	return s.withAssets(ctx, withVideoAngle(UnmarshallExercise(r), req.GetVideoAngle())), err
 }
 
 //UpdateExercise updates an existing record, changing everything but the exercise id
 func (s *API) UpdateExercise(ctx context.Context, req *pbexrs.UpdateRequest) (*pbexrs.Exercise, error) {
 	log := ctxzap.Extract(ctx).Sugar()
+	if err := s.validateExercise(ctx, req.Exercise); err != nil {
+		log.Debugf("rejected update of exercise %v. Error was %v", req.GetId(), err)
+		return &pbexrs.Exercise{}, err
+	}
 	e := MarshallExercise(req.Exercise)
+	e.UpdateTime, e.UpdatedBy = now(), auth.Subject(ctx)
 	log.Debugf("updating exercise with id %v", req.GetId())
-	r, err := s.ExerciseStorage.Update(req.GetId(), e)
+	var r *storage.Exercise
+	err := s.tx.InTransaction(ctx, func(ctx context.Context) (err error) {
+		if r, err = s.ExerciseStorage.Update(ctx, req.GetId(), e); err != nil {
+			return err
+		}
+		return s.record(ctx, storage.RevisionUpdate, req.GetId(), r)
+	})
 	if err != nil {
 		log.Warnf("could not update exercise %v. Error was %v", req.GetId(), err)
 		return &pbexrs.Exercise{}, err
 	}
 	log.Debugf("updated exercise %v", req.GetId())
-	return UnmarshallExercise(r), err
+	return s.withAssets(ctx, UnmarshallExercise(r)), err
 }
 
-//DeleteExercise deletes an exercise by Id, if doesnt exists, returns an error
+//DeleteExercise deletes an exercise by Id, if doesnt exists, returns NotFound. The entities
+//referencing it are handled according to the policy of the request, in the same unit of work
 func (s *API) DeleteExercise(ctx context.Context, req *pbexrs.DeleteRequest) (*empty.Empty, error) {
 	log := ctxzap.Extract(ctx).Sugar()
-	log.Debugf("deleting exercise with id %v", req.GetId())
-	_, err := s.ExerciseStorage.Delete(req.GetId())
+	policy := deletePolicy(req.GetPolicy())
+	log.Debugf("deleting exercise with id %v, %v references", req.GetId(), policy)
+	err := s.tx.InTransaction(ctx, func(ctx context.Context) error {
+		//restrict only looks the references up, so it refuses before anything is deleted
+		if policy == references.Restrict {
+			if err := s.references.Release(ctx, req.GetId(), policy); err != nil {
+				return err
+			}
+		}
+		//the exercise goes first, a missing one must not cascade or unlink the references to its id
+		found, err := s.ExerciseStorage.Delete(ctx, req.GetId())
+		if err != nil {
+			return err
+		}
+		if !found {
+			return status.Errorf(codes.NotFound, "exercise %s not found", req.GetId())
+		}
+		if policy != references.Restrict {
+			if err := s.references.Release(ctx, req.GetId(), policy); err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, storage.RevisionDelete, req.GetId(), nil)
+	})
 	if err != nil {
-		log.Warnf("failed to delete exercise with id %v", req.GetId())
+		log.Warnf("failed to delete exercise with id %v. Error was %v", req.GetId(), err)
 		return &emptypb.Empty{}, err
 	}
 	return &empty.Empty{}, err
 }
 
-//ListExercises returns a paged list of exercises
+//ListExercises returns a paged list of exercises, every exercise when page_size is not set
 func (s *API) ListExercises(ctx context.Context, req *pbexrs.ListExercisesRequest) (*pbexrs.ListExercisesResponse, error) {
 	log := ctxzap.Extract(ctx).Sugar()
 	log.Debugf("[Request] Listing exercises")
-	l, err := s.ExerciseStorage.List()
+	offset, err := pageOffset(req.GetPageToken())
+	if err != nil {
+		return &pbexrs.ListExercisesResponse{}, err
+	}
+	f := storage.Filter{
+		Difficulty: enumToStorage(int32(req.GetDifficulty()), pbexrs.Difficulty_name),
+		Offset:     offset,
+	}
+	if req.GetUpdatedAfter() != nil {
+		f.UpdatedAfter = fromTimestamp(req.GetUpdatedAfter())
+	}
+	//one more than the page tells whether there is a next one
+	size := int(req.GetPageSize())
+	if size > 0 {
+		f.Limit = size + 1
+	}
+	l, err := s.ExerciseStorage.List(ctx, f)
 	if err != nil {
 		log.Warnf("failed to get list of exercises")
 		return &pbexrs.ListExercisesResponse{}, err
 	}
+	var next string
+	if size > 0 && len(l) > size {
+		l, next = l[:size], pageToken(offset+size)
+	}
 	ul := UnmarshallExerciseList(l)
 	if ul == nil { //in case returned list is nil, this funciton never returns nil
 		ul = []*pbexrs.Exercise{}
 	}
+	for _, e := range ul {
+		s.withAssets(ctx, withVideoAngle(e, req.GetVideoAngle()))
+	}
 	log.Debugf("[Response] list %v with error %v", l, err)
-	return &pbexrs.ListExercisesResponse{Exercises: ul}, err
+	return &pbexrs.ListExercisesResponse{Exercises: ul, NextPageToken: next}, err
+}
+
+//validateExercise checks the parts of an exercise that depend on stored data, the taxonomy and
+//the referenced assets. Stateless checks are declared in Rules
+func (s *API) validateExercise(ctx context.Context, e *pbexrs.Exercise) error {
+	var violations validation.Violations
+	if e == nil {
+		violations.Add("exercise", "is required")
+		return violations.Err("invalid exercise")
+	}
+	if err := s.checkTaxonomy(ctx, e, &violations); err != nil {
+		return err
+	}
+	if err := s.checkAssets(ctx, e, &violations); err != nil {
+		return err
+	}
+	return violations.Err("invalid exercise")
 }
 
 //UnmarshallExerciseList converts a list of storage Exercises into transport layer exercises
@@ -107,18 +217,27 @@ func UnmarshallExerciseList(l []*storage.Exercise) []*pbexrs.Exercise {
 }
 
 //MarshallExercise converts a storage layer exercise struct transport layer exercise into an
+//Output only fields, like the create and update times, are never taken from the client
 func MarshallExercise(e *pbexrs.Exercise) *storage.Exercise {
 	if e == nil {
 		return nil
 	}
 	return &storage.Exercise{Id: e.Id,
-		Name:         e.Name,
-		Kind:         e.Kind,
-		Categories:   e.Categories,
-		Muscles:      e.Muscles,
-		MuscleGroups: e.MuscleGroups,
-		Images:       e.Images,
-		Videos:       e.Videos,
+		Name:           e.Name,
+		Kind:           enumToStorage(int32(e.Kind), pbexrs.Kind_name),
+		Categories:     e.Categories,
+		Muscles:        e.Muscles,
+		MuscleGroups:   e.MuscleGroups,
+		Images:         e.Images,
+		Videos:         MarshallVideos(e.Videos),
+		Description:    e.Description,
+		Instructions:   e.Instructions,
+		Tips:           e.Tips,
+		CommonMistakes: e.CommonMistakes,
+		Difficulty:     enumToStorage(int32(e.Difficulty), pbexrs.Difficulty_name),
+		Mechanics:      enumToStorage(int32(e.Mechanics), pbexrs.Mechanics_name),
+		Force:          enumToStorage(int32(e.Force), pbexrs.Force_name),
+		ImageIDs:       e.ImageIds,
 	}
 }
 
@@ -128,12 +247,72 @@ func UnmarshallExercise(e *storage.Exercise) *pbexrs.Exercise {
 		return nil
 	}
 	return &pbexrs.Exercise{Id: e.Id,
-		Name:         e.Name,
-		Kind:         e.Kind,
-		Categories:   e.Categories,
-		Muscles:      e.Muscles,
-		MuscleGroups: e.MuscleGroups,
-		Images:       e.Images,
-		Videos:       e.Videos,
+		Name:           e.Name,
+		Kind:           pbexrs.Kind(enumFromStorage(e.Kind, pbexrs.Kind_value)),
+		Categories:     e.Categories,
+		Muscles:        e.Muscles,
+		MuscleGroups:   e.MuscleGroups,
+		Images:         e.Images,
+		Videos:         UnmarshallVideos(e.Videos),
+		Description:    e.Description,
+		Instructions:   e.Instructions,
+		Tips:           e.Tips,
+		CommonMistakes: e.CommonMistakes,
+		Difficulty:     pbexrs.Difficulty(enumFromStorage(e.Difficulty, pbexrs.Difficulty_value)),
+		Mechanics:      pbexrs.Mechanics(enumFromStorage(e.Mechanics, pbexrs.Mechanics_value)),
+		Force:          pbexrs.Force(enumFromStorage(e.Force, pbexrs.Force_value)),
+		ImageIds:       e.ImageIDs,
+		CreateTime:     toTimestamp(e.CreateTime),
+		UpdateTime:     toTimestamp(e.UpdateTime),
+		CreatedBy:      e.CreatedBy,
+		UpdatedBy:      e.UpdatedBy,
+	}
+}
+
+//now is the time set on writes, truncated to the precision the storage keeps
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+//fromTimestamp converts a proto timestamp, nil timestamps are the zero time
+func fromTimestamp(t *timestamp.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	tt, _ := ptypes.Timestamp(t)
+	return tt
+}
+
+//toTimestamp converts a stored time, the zero time is returned as not set
+func toTimestamp(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
 	}
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+//enumToStorage stores an enum value by its lowercase name, unspecified values are stored empty
+func enumToStorage(v int32, names map[int32]string) string {
+	if v == 0 {
+		return ""
+	}
+	return strings.ToLower(names[v])
+}
+
+//Kinds returns the storage representation of every known kind
+func Kinds() []string {
+	var kinds []string
+	for v := range pbexrs.Kind_name {
+		if k := enumToStorage(v, pbexrs.Kind_name); k != "" {
+			kinds = append(kinds, k)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+//enumFromStorage converts a stored enum name back into its value, unknown names are unspecified
+func enumFromStorage(s string, values map[string]int32) int32 {
+	return values[strings.ToUpper(s)]
 }
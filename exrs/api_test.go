@@ -4,6 +4,9 @@ package exrs
 
 import (
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/maxvw8/exercise_lib/exrs/storage"
 	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
@@ -24,24 +27,38 @@ func TestMarshall(t *testing.T) {
 		{
 			Name: "full instance",
 			Input: &pbexrs.Exercise{
-				Id:           "id",
-				Name:         "name",
-				Category:     []string{"category"},
-				Kind:         "kind",
-				Images:       []string{"images"},
-				Videos:       []string{"yutub"},
-				Muscles:      []string{"muscles"},
-				MuscleGroups: []string{"muscle groups"},
+				Id:             "id",
+				Name:           "name",
+				Categories:     []string{"category"},
+				Kind:           pbexrs.Kind_ANAEROBIC,
+				Images:         []string{"images"},
+				Videos:         []*pbexrs.Video{{Provider: pbexrs.VideoProvider_YOUTUBE, Url: "yutub", StartOffset: ptypes.DurationProto(90 * time.Second), Angle: pbexrs.CameraAngle_SIDE}},
+				Muscles:        []string{"muscles"},
+				MuscleGroups:   []string{"muscle groups"},
+				Description:    "description",
+				Instructions:   []string{"step 1", "step 2"},
+				Tips:           []string{"tip"},
+				CommonMistakes: []string{"mistake"},
+				Difficulty:     pbexrs.Difficulty_INTERMEDIATE,
+				Mechanics:      pbexrs.Mechanics_COMPOUND,
+				Force:          pbexrs.Force_PUSH,
 			},
 			Expected: &storage.Exercise{
-				Id:           "id",
-				Name:         "name",
-				Category:     []string{"category"},
-				Kind:         "kind",
-				Images:       []string{"images"},
-				Videos:       []string{"yutub"},
-				Muscles:      []string{"muscles"},
-				MuscleGroups: []string{"muscle groups"},
+				Id:             "id",
+				Name:           "name",
+				Categories:     []string{"category"},
+				Kind:           "anaerobic",
+				Images:         []string{"images"},
+				Videos:         []storage.Video{{Provider: "youtube", URL: "yutub", StartOffset: 90 * time.Second, Angle: "side"}},
+				Muscles:        []string{"muscles"},
+				MuscleGroups:   []string{"muscle groups"},
+				Description:    "description",
+				Instructions:   []string{"step 1", "step 2"},
+				Tips:           []string{"tip"},
+				CommonMistakes: []string{"mistake"},
+				Difficulty:     "intermediate",
+				Mechanics:      "compound",
+				Force:          "push",
 			},
 		},
 	}
@@ -68,24 +85,38 @@ func TestUnarshall(t *testing.T) {
 		{
 			Name: "full instance",
 			Input: &storage.Exercise{
-				Id:           "id",
-				Name:         "name",
-				Category:     []string{"category"},
-				Kind:         "kind",
-				Images:       []string{"images"},
-				Videos:       []string{"yutub"},
-				Muscles:      []string{"muscles"},
-				MuscleGroups: []string{"muscle groups"},
+				Id:             "id",
+				Name:           "name",
+				Categories:     []string{"category"},
+				Kind:           "anaerobic",
+				Images:         []string{"images"},
+				Videos:         []storage.Video{{Provider: "youtube", URL: "yutub", StartOffset: 90 * time.Second, Angle: "side"}},
+				Muscles:        []string{"muscles"},
+				MuscleGroups:   []string{"muscle groups"},
+				Description:    "description",
+				Instructions:   []string{"step 1", "step 2"},
+				Tips:           []string{"tip"},
+				CommonMistakes: []string{"mistake"},
+				Difficulty:     "intermediate",
+				Mechanics:      "compound",
+				Force:          "push",
 			},
 			Expected: &pbexrs.Exercise{
-				Id:           "id",
-				Name:         "name",
-				Category:     []string{"category"},
-				Kind:         "kind",
-				Images:       []string{"images"},
-				Videos:       []string{"yutub"},
-				Muscles:      []string{"muscles"},
-				MuscleGroups: []string{"muscle groups"},
+				Id:             "id",
+				Name:           "name",
+				Categories:     []string{"category"},
+				Kind:           pbexrs.Kind_ANAEROBIC,
+				Images:         []string{"images"},
+				Videos:         []*pbexrs.Video{{Provider: pbexrs.VideoProvider_YOUTUBE, Url: "yutub", StartOffset: ptypes.DurationProto(90 * time.Second), Angle: pbexrs.CameraAngle_SIDE}},
+				Muscles:        []string{"muscles"},
+				MuscleGroups:   []string{"muscle groups"},
+				Description:    "description",
+				Instructions:   []string{"step 1", "step 2"},
+				Tips:           []string{"tip"},
+				CommonMistakes: []string{"mistake"},
+				Difficulty:     pbexrs.Difficulty_INTERMEDIATE,
+				Mechanics:      pbexrs.Mechanics_COMPOUND,
+				Force:          pbexrs.Force_PUSH,
 			},
 		},
 	}
@@ -98,24 +129,76 @@ func TestUnarshall(t *testing.T) {
 	}
 }
 
+func TestOutputOnlyFields(t *testing.T) {
+	created := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	stored := &storage.Exercise{
+		Id:         "id",
+		CreateTime: created,
+		UpdateTime: updated,
+		CreatedBy:  "alice",
+		UpdatedBy:  "bob",
+	}
+	e := UnmarshallExercise(stored)
+	assert.Equal(t, created.Unix(), e.CreateTime.Seconds)
+	assert.Equal(t, updated.Unix(), e.UpdateTime.Seconds)
+	assert.Equal(t, "alice", e.CreatedBy)
+	assert.Equal(t, "bob", e.UpdatedBy)
+	//sending back a read exercise must not change its metadata
+	assert.Equal(t, &storage.Exercise{Id: "id"}, MarshallExercise(e))
+}
+
+func TestEnumStorage(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    pbexrs.Difficulty
+		Expected string
+	}{
+		{
+			Name:     "unspecified",
+			Input:    pbexrs.Difficulty_DIFFICULTY_UNSPECIFIED,
+			Expected: "",
+		},
+		{
+			Name:     "beginner",
+			Input:    pbexrs.Difficulty_BEGINNER,
+			Expected: "beginner",
+		},
+		{
+			Name:     "advanced",
+			Input:    pbexrs.Difficulty_ADVANCED,
+			Expected: "advanced",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			s := enumToStorage(int32(tc.Input), pbexrs.Difficulty_name)
+			assert.Equal(t, tc.Expected, s)
+			assert.Equal(t, tc.Input, pbexrs.Difficulty(enumFromStorage(s, pbexrs.Difficulty_value)))
+		})
+	}
+}
+
 func TestUnmarshallList(t *testing.T) {
 	storageSample := &storage.Exercise{
 		Id:           "id",
 		Name:         "name",
-		Category:     []string{"category"},
-		Kind:         "kind",
+		Categories:   []string{"category"},
+		Kind:         "anaerobic",
 		Images:       []string{"images"},
-		Videos:       []string{"yutub"},
+		Videos:       []storage.Video{{Provider: "youtube", URL: "yutub", StartOffset: 90 * time.Second, Angle: "side"}},
 		Muscles:      []string{"muscles"},
 		MuscleGroups: []string{"muscle groups"},
 	}
 	pbexrsSample := &pbexrs.Exercise{
 		Id:           "id",
 		Name:         "name",
-		Category:     []string{"category"},
-		Kind:         "kind",
+		Categories:   []string{"category"},
+		Kind:         pbexrs.Kind_ANAEROBIC,
 		Images:       []string{"images"},
-		Videos:       []string{"yutub"},
+		Videos:       []*pbexrs.Video{{Provider: pbexrs.VideoProvider_YOUTUBE, Url: "yutub", StartOffset: ptypes.DurationProto(90 * time.Second), Angle: pbexrs.CameraAngle_SIDE}},
 		Muscles:      []string{"muscles"},
 		MuscleGroups: []string{"muscle groups"},
 	}
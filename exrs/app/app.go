@@ -0,0 +1,348 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/maxvw8/exercise_lib/exrs"
+	"github.com/maxvw8/exercise_lib/exrs/assets"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/certs"
+	"github.com/maxvw8/exercise_lib/exrs/health"
+	"github.com/maxvw8/exercise_lib/exrs/httpcache"
+	"github.com/maxvw8/exercise_lib/exrs/metrics"
+	"github.com/maxvw8/exercise_lib/exrs/migration"
+	"github.com/maxvw8/exercise_lib/exrs/ratelimit"
+	"github.com/maxvw8/exercise_lib/exrs/references"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/storage/boltdb"
+	"github.com/maxvw8/exercise_lib/exrs/storage/cache"
+	"github.com/maxvw8/exercise_lib/exrs/storage/mongodb"
+	"github.com/maxvw8/exercise_lib/exrs/storage/postgres"
+	"github.com/maxvw8/exercise_lib/exrs/tracing"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+//UploadMethod names the asset uploads of the gateway for the auth and the rate limits, list it in
+//the rate limit methods as UploadAsset to give them their own limit
+const UploadMethod = "/pbexrs.ExerciseService/UploadAsset"
+
+//App holds what the modes share. Storage is only opened by the modes serving or moving exercises
+type App struct {
+	Config  Config
+	Logger  *zap.Logger
+	Metrics *metrics.Metrics
+	Media   *assets.Service
+	//API is nil until OpenStorage
+	API *exrs.API
+	//RateLimits keeps the buckets of the callers in memory, replace it before serving to share
+	//them between servers
+	RateLimits ratelimit.Store
+	//Cache of the exercises read, in memory unless replaced before OpenStorage. Nil disables it
+	Cache cache.Cache
+	//References to the exercises, the subsystems holding exercise ids register in it before serving
+	References *references.Registry
+
+	repo            repository
+	shutdownTracing func(context.Context) error
+}
+
+//repository is what the storage backends provide
+type repository interface {
+	storage.ExerciseStorage
+	storage.TaxonomyStorage
+	storage.RevisionStorage
+	storage.Transactor
+	Close() error
+}
+
+//New sets up logging, tracing, metrics and assets
+func New(cfg Config) (*App, error) {
+	if err := cfg.RateLimits.Validate(); err != nil {
+		return nil, err
+	}
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		return nil, fmt.Errorf("could not create logger. Error was %v", err)
+	}
+	shutdownTracing, err := tracing.Setup(cfg.TraceExporter, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := assets.NewFileStore(cfg.AssetsDir)
+	if err != nil {
+		return nil, err
+	}
+	a := &App{
+		Config:          cfg,
+		Logger:          logger,
+		Metrics:         metrics.New(),
+		Media:           assets.New(blobs, assets.Options{PublicURL: cfg.PublicURL}),
+		RateLimits:      ratelimit.NewMemoryStore(),
+		References:      references.NewRegistry(),
+		shutdownTracing: shutdownTracing,
+	}
+	if cfg.Cache.Size > 0 {
+		a.Cache = cache.NewMemory(cfg.Cache.Size)
+	}
+	return a, nil
+}
+
+//OpenStorage opens the storage backend of the config and creates the API on top of it
+func (a *App) OpenStorage() error {
+	var repo repository
+	var err error
+	switch a.Config.Storage {
+	case StorageMongo:
+		repo, err = mongodb.New(a.Config.Database, a.Config.Mongo)
+	case StorageBolt:
+		repo, err = boltdb.New(a.Config.BoltFile)
+	case StoragePostgres:
+		repo, err = postgres.New(a.Config.PostgresDSN)
+	default:
+		err = fmt.Errorf("unknown storage %q, expected %v, %v or %v", a.Config.Storage, StorageMongo, StorageBolt, StoragePostgres)
+	}
+	if err != nil {
+		return err
+	}
+	if c, ok := repo.(prometheus.Collector); ok {
+		a.Metrics.Registry.MustRegister(c)
+	}
+	var exercises storage.ExerciseStorage = a.Metrics.NewStorage(repo)
+	if a.Cache != nil {
+		cached := cache.New(exercises, a.Cache, a.Config.Cache.TTL)
+		a.Metrics.Registry.MustRegister(cached)
+		exercises = cached
+	}
+	api, err := exrs.Server(tracing.NewStorage(exercises), repo, exrs.WithAssets(a.Media),
+		exrs.WithRevisions(repo), exrs.WithTransactions(repo), exrs.WithReferences(a.References),
+		exrs.WithEquipment(a.Config.Equipment))
+	if err != nil {
+		repo.Close()
+		return err
+	}
+	a.repo, a.API = repo, api
+	return nil
+}
+
+//Migrations of the documents of the storage. The postgres storage has none, it migrates its
+//schema when it opens. It needs OpenStorage
+func (a *App) Migrations() (*migration.Runner, error) {
+	switch repo := a.repo.(type) {
+	case *mongodb.Storage:
+		return migration.NewRunner(repo.MigrationStore(), repo.Migrations(exrs.Kinds(), exrs.VideoProvider))
+	case *boltdb.Storage:
+		return migration.NewRunner(repo.MigrationStore(), repo.Migrations())
+	}
+	return nil, fmt.Errorf("the %v storage migrates its schema when it opens", a.Config.Storage)
+}
+
+//Migrate applies the pending migrations when MigrateOnStart is set
+func (a *App) Migrate(ctx context.Context) error {
+	if !a.Config.MigrateOnStart || a.Config.Storage == StoragePostgres {
+		return nil
+	}
+	r, err := a.Migrations()
+	if err != nil {
+		return err
+	}
+	ran, err := r.Up(ctx)
+	for _, m := range ran {
+		a.Logger.Info("applied migration", zap.Int("version", m.Version), zap.String("name", m.Name))
+	}
+	return err
+}
+
+//Close flushes the traces and closes the storage last
+func (a *App) Close(ctx context.Context) error {
+	defer a.Logger.Sync()
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.Logger.Error("failed to flush traces", zap.Error(err))
+	}
+	if a.repo == nil {
+		return nil
+	}
+	return a.repo.Close()
+}
+
+//GRPCServer creates the gRPC server exposing the API and its health, every mode gets the same interceptors.
+//It fails when the validation rules reference unknown fields
+func (a *App) GRPCServer(checker *health.Checker, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	validate, err := validation.UnaryServerInterceptor(exrs.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validation rules. Error was %v", err)
+	}
+	interceptors := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(),
+		a.Metrics.UnaryServerInterceptor(),
+		grpc_zap.UnaryServerInterceptor(a.Logger),
+	}
+	interceptors = append(interceptors, a.callerInterceptors()...)
+	interceptors = append(interceptors, validate)
+	opts = append(opts, grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(interceptors...)))
+	s := grpc.NewServer(opts...)
+	pbexrs.RegisterExerciseServiceServer(s, a.API)
+	healthpb.RegisterHealthServer(s, checker)
+	a.Metrics.InitializeMetrics(s)
+	return s, nil
+}
+
+//Gateway creates the REST gateway proxying to the gRPC server at endpoint, next to the asset
+//uploads, the docs, /metrics and the checks. The gateway stops proxying once ctx is done
+func (a *App) Gateway(ctx context.Context, endpoint string, ready health.Check, opts ...grpc.DialOption) (http.Handler, error) {
+	m := a.Metrics
+	gwmux := runtime.NewServeMux(
+		runtime.WithProtoErrorHandler(ratelimit.HTTPError),
+		runtime.WithForwardResponseOption(httpcache.ForwardResponseOption),
+		runtime.WithMetadata(auth.ForwardCertificate),
+		runtime.WithIncomingHeaderMatcher(auth.HeaderMatcher),
+	)
+	opts = append(opts, grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()))
+	if err := pbexrs.RegisterExerciseServiceHandlerFromEndpoint(ctx, gwmux, endpoint, opts); err != nil {
+		return nil, fmt.Errorf("could not register gateway to %v. Error was %v", endpoint, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", m.InstrumentHandler(httpcache.Handler(gwmux, a.Config.CacheControl)))
+	assetsHandler := assets.Handler(a.Media, a.Logger)
+	mux.Handle("/v1/assets", m.InstrumentHandler(a.guarded(UploadMethod, assetsHandler, gwmux)))
+	mux.Handle(assets.PathPrefix, m.InstrumentHandler(assetsHandler))
+	docs := Docs()
+	mux.Handle("/openapi.json", docs)
+	mux.Handle("/docs", docs)
+	mux.Handle("/docs/", docs)
+	a.sideRoutes(mux, ready)
+	return tracing.Handler(mux), nil
+}
+
+//callerInterceptors authenticate and rate limit the callers, the gRPC server and the routes of
+//the gateway that are not proxied share them
+func (a *App) callerInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		auth.CertificateInterceptor(a.Config.TLS.GatewaySubjects...),
+		auth.UnaryServerInterceptor(a.Config.AuthTokens),
+		ratelimit.UnaryServerInterceptor(a.Config.RateLimits, a.RateLimits),
+	}
+}
+
+//guarded serves h as the gRPC method given, callers authenticate and are rate limited like the
+//API calls. Errors are written like the gateway ones
+func (a *App) guarded(method string, h http.Handler, mux *runtime.ServeMux) http.Handler {
+	chain := grpc_middleware.ChainUnaryServer(a.callerInterceptors()...)
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ctxzap.ToContext(r.Context(), a.Logger)
+		ctx = metadata.NewIncomingContext(ctx, incomingMetadata(r))
+		p := &peer.Peer{Addr: remoteAddr(r.RemoteAddr)}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+		}
+		ctx = peer.NewContext(ctx, p)
+		_, err := chain(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			h.ServeHTTP(w, r.WithContext(ctx))
+			return nil, nil
+		})
+		if err != nil {
+			_, marshaler := runtime.MarshalerForRequest(mux, r)
+			ratelimit.HTTPError(ctx, mux, marshaler, w, r, err)
+		}
+	})
+}
+
+//incomingMetadata of r the interceptors read, as the gateway forwards them
+func incomingMetadata(r *http.Request) metadata.MD {
+	md := metadata.MD{"authorization": r.Header.Values("Authorization")}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		forwarded := host
+		if prior := r.Header.Get("X-Forwarded-For"); prior != "" {
+			forwarded = prior + ", " + host
+		}
+		md.Set("x-forwarded-for", forwarded)
+	}
+	return md
+}
+
+//remoteAddr is the address of the client of an HTTP request
+type remoteAddr string
+
+func (remoteAddr) Network() string  { return "tcp" }
+func (a remoteAddr) String() string { return string(a) }
+
+//sideRoutes serves /metrics, /healthz and /readyz
+func (a *App) sideRoutes(mux *http.ServeMux, ready health.Check) {
+	mux.Handle("/metrics", a.Metrics.Handler())
+	checks := health.Handler(ready)
+	mux.Handle("/healthz", checks)
+	mux.Handle("/readyz", checks)
+}
+
+//serverTLS serves the certificate of the servers, reloaded until ctx is done. It is nil when they
+//serve plain text
+func (a *App) serverTLS(ctx context.Context) (*tls.Config, error) {
+	t := a.Config.TLS
+	if t.Cert == "" {
+		return nil, nil
+	}
+	r, err := a.reloader(ctx, t.Cert, t.Key, t.ClientCA)
+	if err != nil {
+		return nil, err
+	}
+	return r.ServerConfig(t.ClientAuthOptional), nil
+}
+
+//dialCredentials to reach the gRPC server at endpoint, verified by ca, presenting the key pair
+//of cert when given. It is plain text when both are empty
+func (a *App) dialCredentials(ctx context.Context, endpoint, ca, cert, key string) (grpc.DialOption, error) {
+	if ca == "" && cert == "" {
+		return grpc.WithInsecure(), nil
+	}
+	var roots *x509.CertPool
+	if ca != "" {
+		var err error
+		if roots, err = certs.LoadPool(ca); err != nil {
+			return nil, err
+		}
+	}
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	config := &tls.Config{ServerName: host, RootCAs: roots}
+	if cert != "" {
+		r, err := a.reloader(ctx, cert, key, "")
+		if err != nil {
+			return nil, err
+		}
+		config = r.ClientConfig(host, roots)
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+}
+
+//reloader loads the files and reloads them on change until ctx is done
+func (a *App) reloader(ctx context.Context, cert, key, ca string) (*certs.Reloader, error) {
+	r, err := certs.NewReloader(cert, key, ca)
+	if err != nil {
+		return nil, err
+	}
+	r.OnError = func(err error) {
+		a.Logger.Error("failed to reload certificates, still serving the previous ones", zap.Error(err))
+	}
+	go r.Run(ctx, certs.ReloadInterval)
+	return r, nil
+}
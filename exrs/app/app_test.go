@@ -0,0 +1,59 @@
+// +build unit
+
+package app
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigParse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "exrs.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("database: fromfile\ngrpc_address: :6000\nshutdown_timeout: 3s\ntls:\n  cert: server.pem\nmongo:\n  max_pool_size: 50\n  retry:\n    attempts: 5\nequipment: [dumbbell, trx]\n"), 0600))
+	testCases := []struct {
+		name     string
+		args     []string
+		expected func(*Config)
+	}{
+		{"defaults", nil, func(*Config) {}},
+		{"flags", []string{"-database", "flag", "-grpc-address", ":7000", "-mongo-read-preference", "nearest"}, func(c *Config) {
+			c.Database, c.GRPCAddress, c.Mongo.ReadPreference = "flag", ":7000", "nearest"
+		}},
+		{"file", []string{"-config", path}, func(c *Config) {
+			c.Database, c.GRPCAddress, c.ShutdownTimeout, c.TLS.Cert = "fromfile", ":6000", 3*time.Second, "server.pem"
+			c.Mongo.MaxPoolSize, c.Mongo.Retry.Attempts = 50, 5
+			c.Equipment = []string{"dumbbell", "trx"}
+		}},
+		{"flags over file", []string{"-database", "flag", "-config", path}, func(c *Config) {
+			c.Database, c.GRPCAddress, c.ShutdownTimeout, c.TLS.Cert = "flag", ":6000", 3*time.Second, "server.pem"
+			c.Mongo.MaxPoolSize, c.Mongo.Retry.Attempts = 50, 5
+			c.Equipment = []string{"dumbbell", "trx"}
+		}},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			expected := DefaultConfig()
+			tc.expected(&expected)
+			c := DefaultConfig()
+			assert.NoError(t, c.Parse(flag.NewFlagSet("test", flag.ContinueOnError), tc.args))
+			assert.Equal(t, expected, c)
+		})
+	}
+}
+
+func TestLoopback(t *testing.T) {
+	assert.Equal(t, "localhost:8080", loopback(":8080"))
+	assert.Equal(t, "localhost:8080", loopback("0.0.0.0:8080"))
+	assert.Equal(t, "example.com:8080", loopback("example.com:8080"))
+}
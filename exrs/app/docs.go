@@ -0,0 +1,20 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/flowchartsman/swaggerui"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+)
+
+//Docs serves the spec of the gateway at /openapi.json and a bundled Swagger UI browsing it at /docs
+func Docs() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pbexrs.OpenAPI)
+	})
+	mux.Handle("/docs/", http.StripPrefix("/docs", swaggerui.Handler(pbexrs.OpenAPI)))
+	mux.Handle("/docs", http.RedirectHandler("/docs/", http.StatusMovedPermanently))
+	return mux
+}
@@ -0,0 +1,85 @@
+// +build unit
+
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDocs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		code     int
+		contains string
+	}{
+		{"spec", "/openapi.json", http.StatusOK, `"swagger": "2.0"`},
+		{"ui", "/docs/", http.StatusOK, "swagger-ui"},
+		{"ui assets", "/docs/swagger-ui-bundle.js", http.StatusOK, "SwaggerUIBundle"},
+		{"spec of the ui", "/docs/swagger_spec", http.StatusOK, `"swagger": "2.0"`},
+		{"no trailing slash", "/docs", http.StatusMovedPermanently, ""},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			Docs().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assert.Equal(t, tc.code, rec.Code)
+			body, _ := ioutil.ReadAll(rec.Body)
+			assert.Contains(t, string(body), tc.contains)
+		})
+	}
+}
+
+//TestOpenAPIInSync fails when the proto changed without regenerating the spec
+func TestOpenAPIInSync(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(pbexrs.OpenAPI, &spec))
+	checked := 0
+	services := pbexrs.File_v1_exercise_service_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			m := methods.Get(j)
+			rule, ok := proto.GetExtension(m.Options(), annotations.E_Http).(*annotations.HttpRule)
+			if !ok || rule == nil {
+				continue
+			}
+			checked++
+			verb, path := httpRule(rule)
+			assert.Contains(t, spec.Paths[path], verb, "%v is not in the spec as %v %v", m.FullName(), verb, path)
+		}
+	}
+	assert.NotZero(t, checked)
+}
+
+func httpRule(r *annotations.HttpRule) (string, string) {
+	switch p := r.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		return "get", p.Get
+	case *annotations.HttpRule_Post:
+		return "post", p.Post
+	case *annotations.HttpRule_Put:
+		return "put", p.Put
+	case *annotations.HttpRule_Patch:
+		return "patch", p.Patch
+	case *annotations.HttpRule_Delete:
+		return "delete", p.Delete
+	case *annotations.HttpRule_Custom:
+		return strings.ToLower(p.Custom.Kind), p.Custom.Path
+	}
+	return "", ""
+}
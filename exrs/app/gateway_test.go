@@ -0,0 +1,189 @@
+// +build unit
+
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/assets"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestGatewayUploads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assets")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	cfg := DefaultConfig()
+	cfg.AssetsDir = dir
+	cfg.AuthTokens = auth.Tokens{"s3cret": "ops"}
+	cfg.RateLimits = ratelimit.Config{Methods: map[string]ratelimit.Limit{"UploadAsset": {Rate: 0.001, Burst: 2}}}
+	a, err := New(cfg)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	gw, err := a.Gateway(ctx, "localhost:1", func(context.Context) error { return nil }, grpc.WithInsecure())
+	require.NoError(t, err)
+
+	upload := func(token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/assets", strings.NewReader("not multipart"))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		gw.ServeHTTP(rec, r)
+		return rec
+	}
+	assert.Equal(t, http.StatusUnauthorized, upload("").Code)
+	assert.Equal(t, http.StatusUnauthorized, upload("guess").Code)
+	assert.Equal(t, http.StatusBadRequest, upload("s3cret").Code, "authenticated uploads reach the handler")
+	assert.Equal(t, http.StatusBadRequest, upload("s3cret").Code)
+	limited := upload("s3cret")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code, "uploads are rate limited")
+	assert.NotEmpty(t, limited.Header().Get("Retry-After"))
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, assets.PathPrefix+"missing.png", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "downloads stay public")
+}
+
+//issue writes a certificate for cn signed by ca, or self signed when ca is nil, and its key to dir
+func issue(t *testing.T, dir, cn string, ca *tls.Certificate) (string, string, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	parent, signer := tmpl, interface{}(key)
+	if ca == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid, tmpl.KeyUsage = true, true, x509.KeyUsageCertSign|x509.KeyUsageDigitalSignature
+	} else {
+		parent, signer = ca.Leaf, ca.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	certFile, keyFile := filepath.Join(dir, cn+".pem"), filepath.Join(dir, cn+".key")
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	pair.Leaf, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certFile, keyFile, pair
+}
+
+//TestGatewayIdentity serves gRPC and the gateway over mTLS, the gateway presenting the server
+//certificate, and checks REST callers are known by their own certificate or token
+func TestGatewayIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gateway")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	caFile, _, ca := issue(t, dir, "ca", nil)
+	serverCert, serverKey, _ := issue(t, dir, "exrs", &ca)
+	_, _, alice := issue(t, dir, "alice", &ca)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	cfg := DefaultConfig()
+	cfg.Storage, cfg.BoltFile, cfg.AssetsDir = StorageBolt, filepath.Join(dir, "exrs.db"), filepath.Join(dir, "assets")
+	cfg.HTTPAddress, cfg.ShutdownTimeout = address, time.Second
+	cfg.TLS = TLSConfig{Cert: serverCert, Key: serverKey, ClientCA: caFile, ClientAuthOptional: true}
+	cfg.AuthTokens = auth.Tokens{"s3cret": "ops"}
+	a, err := New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, a.OpenStorage())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.ServeAll(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+		a.Close(context.Background())
+	})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+	client := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs},
+		}}
+	}
+	require.Eventually(t, func() bool {
+		resp, err := client().Get("https://" + address + "/readyz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+
+	testCases := []struct {
+		name      string
+		client    *http.Client
+		headers   map[string]string
+		code      int
+		createdBy string
+	}{
+		{"client certificate", client(alice), nil, http.StatusOK, "alice"},
+		{"token", client(), map[string]string{"Authorization": "Bearer s3cret"}, http.StatusOK, "ops"},
+		{"gateway certificate is not enough", client(), nil, http.StatusUnauthorized, ""},
+		{"forged identity", client(), map[string]string{"Authorization": "Bearer s3cret", "Grpc-Metadata-X-Exrs-Client-Subject": "alice"}, http.StatusOK, "ops"},
+		{"forged identity without token", client(), map[string]string{"Grpc-Metadata-X-Exrs-Client-Subject": "alice"}, http.StatusUnauthorized, ""},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := http.NewRequest(http.MethodPost, "https://"+address+"/v1/exercises", strings.NewReader(`{"name": "Squat", "kind": "ANAEROBIC"}`))
+			require.NoError(t, err)
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			resp, err := tc.client.Do(r)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.code, resp.StatusCode)
+			if tc.code != http.StatusOK {
+				return
+			}
+			var created struct {
+				CreatedBy string `json:"created_by"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+			assert.Equal(t, tc.createdBy, created.CreatedBy)
+		})
+	}
+}
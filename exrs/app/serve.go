@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/certs"
+	"github.com/maxvw8/exercise_lib/exrs/health"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+//ServeGRPC serves the API over gRPC on GRPCAddress, and /metrics and the checks on MetricsAddress,
+//until ctx is done. It needs OpenStorage
+func (a *App) ServeGRPC(ctx context.Context) error {
+	tlsConfig, err := a.serverTLS(ctx)
+	if err != nil {
+		return err
+	}
+	var opts []grpc.ServerOption
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	checker := health.NewChecker(a.API)
+	s, err := a.GRPCServer(checker, opts...)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", a.Config.GRPCAddress)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v. Error was %v", a.Config.GRPCAddress, err)
+	}
+	mux := http.NewServeMux()
+	a.sideRoutes(mux, a.API.Ping)
+	side := &http.Server{Addr: a.Config.MetricsAddress, Handler: mux}
+	checking, stopChecking := context.WithCancel(context.Background())
+	defer stopChecking()
+	go checker.Run(checking, health.Interval)
+
+	errc := make(chan error, 2)
+	go func() {
+		errc <- s.Serve(lis)
+	}()
+	go func() {
+		if err := side.ListenAndServe(); err != http.ErrServerClosed {
+			errc <- err
+		}
+	}()
+	a.Logger.Info("serving gRPC", zap.String("address", a.Config.GRPCAddress), zap.String("metrics", a.Config.MetricsAddress))
+	select {
+	case <-ctx.Done():
+	case err = <-errc:
+	}
+	// Stop reporting SERVING first so load balancers move away
+	stopChecking()
+	a.gracefulStop(s)
+	a.shutdown(side)
+	return err
+}
+
+//ServeGateway serves the REST gateway on HTTPAddress, proxying to the gRPC server at Endpoint,
+//until ctx is done. It is ready when the gRPC server reports SERVING
+func (a *App) ServeGateway(ctx context.Context) error {
+	tlsConfig, err := a.serverTLS(ctx)
+	if err != nil {
+		return err
+	}
+	t := a.Config.TLS
+	creds, err := a.dialCredentials(ctx, a.Config.Endpoint, t.CA, t.ClientCert, t.ClientKey)
+	if err != nil {
+		return err
+	}
+	//the connections to the gRPC server live until the gateway is drained
+	proxying, stopProxying := context.WithCancel(context.Background())
+	defer stopProxying()
+	conn, err := grpc.DialContext(proxying, a.Config.Endpoint, creds)
+	if err != nil {
+		return fmt.Errorf("could not dial %v. Error was %v", a.Config.Endpoint, err)
+	}
+	defer conn.Close()
+	gw, err := a.Gateway(proxying, a.Config.Endpoint, health.Remote(healthpb.NewHealthClient(conn)), creds)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Addr: a.Config.HTTPAddress, Handler: gw, TLSConfig: tlsConfig}
+	a.Logger.Info("serving gateway", zap.String("address", a.Config.HTTPAddress), zap.String("endpoint", a.Config.Endpoint))
+	return a.serveHTTP(ctx, srv)
+}
+
+//ServeAll serves gRPC and the gateway on HTTPAddress, telling them apart by content type, until
+//ctx is done. Without a certificate HTTP/2 runs in clear text. It needs OpenStorage
+func (a *App) ServeAll(ctx context.Context) error {
+	tlsConfig, err := a.serverTLS(ctx)
+	if err != nil {
+		return err
+	}
+	//the gateway dials the gRPC server through the same port, trusting and presenting the server
+	//certificate unless told otherwise
+	endpoint := loopback(a.Config.HTTPAddress)
+	creds := grpc.WithInsecure()
+	if t := a.Config.TLS; tlsConfig != nil {
+		ca, cert, key := t.CA, t.ClientCert, t.ClientKey
+		if ca == "" {
+			ca = t.Cert
+		}
+		if cert == "" {
+			cert, key = t.Cert, t.Key
+		}
+		if creds, err = a.dialCredentials(ctx, endpoint, ca, cert, key); err != nil {
+			return err
+		}
+		//the gateway is not the caller, it forwards the callers
+		leaf, err := certs.LoadLeaf(cert)
+		if err != nil {
+			return err
+		}
+		a.Config.TLS.GatewaySubjects = append(a.Config.TLS.GatewaySubjects, auth.FromCertificate(leaf).Subject)
+	}
+	checker := health.NewChecker(a.API)
+	s, err := a.GRPCServer(checker)
+	if err != nil {
+		return err
+	}
+	checking, stopChecking := context.WithCancel(context.Background())
+	defer stopChecking()
+	go checker.Run(checking, health.Interval)
+	proxying, stopProxying := context.WithCancel(context.Background())
+	defer stopProxying()
+	gw, err := a.Gateway(proxying, endpoint, a.API.Ping, creds)
+	if err != nil {
+		return err
+	}
+	handler := grpcHandlerFunc(s, gw)
+	if tlsConfig == nil {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+	srv := &http.Server{Addr: a.Config.HTTPAddress, Handler: handler, TLSConfig: tlsConfig}
+	a.Logger.Info("serving gRPC and gateway", zap.String("address", a.Config.HTTPAddress))
+	go func() {
+		<-ctx.Done()
+		// Stop reporting SERVING first so load balancers move away
+		stopChecking()
+	}()
+	err = a.serveHTTP(ctx, srv)
+	// gRPC calls are served through the http server, draining it drained them
+	s.Stop()
+	return err
+}
+
+//serveHTTP serves srv until ctx is done then drains it
+func (a *App) serveHTTP(ctx context.Context, srv *http.Server) error {
+	lis, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %v. Error was %v", srv.Addr, err)
+	}
+	if srv.TLSConfig != nil {
+		lis = tls.NewListener(lis, srv.TLSConfig)
+	}
+	errc := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(lis); err != http.ErrServerClosed {
+			errc <- err
+		}
+	}()
+	select {
+	case <-ctx.Done():
+	case err = <-errc:
+	}
+	a.shutdown(srv)
+	return err
+}
+
+//shutdown drains srv, closing the remaining connections after ShutdownTimeout
+func (a *App) shutdown(srv *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.Config.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		a.Logger.Warn("requests still running, forcing stop", zap.Duration("timeout", a.Config.ShutdownTimeout), zap.Error(err))
+		srv.Close()
+	}
+}
+
+//gracefulStop lets in flight calls finish, cutting them after ShutdownTimeout
+func (a *App) gracefulStop(s *grpc.Server) {
+	timer := time.AfterFunc(a.Config.ShutdownTimeout, func() {
+		a.Logger.Warn("calls still running, forcing stop", zap.Duration("timeout", a.Config.ShutdownTimeout))
+		s.Stop()
+	})
+	defer timer.Stop()
+	s.GracefulStop()
+}
+
+func grpcHandlerFunc(grpcServer *grpc.Server, httpHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.Contains(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+		} else {
+			httpHandler.ServeHTTP(w, r)
+		}
+	})
+}
+
+//loopback turns a listening address like :8080 into one to dial
+func loopback(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,232 @@
+//Package assets stores uploaded media, like exercise images and videos, and generates thumbnails
+package assets
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	//ErrUnsupportedType is returned when uploading content that is not an allowed image or video
+	ErrUnsupportedType = errors.New("unsupported content type")
+	//ErrTooLarge is returned when the uploaded content exceeds the limit of its type
+	ErrTooLarge = errors.New("content too large")
+)
+
+//Content types accepted on upload, detected from the content itself
+var (
+	imageTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+	videoTypes = map[string]bool{"video/mp4": true, "video/webm": true}
+)
+
+//Asset describes an uploaded file
+type Asset struct {
+	ID           string    `json:"id"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Filename     string    `json:"filename,omitempty"`
+	Thumbnail    bool      `json:"thumbnail"`
+	CreateTime   time.Time `json:"create_time"`
+	URL          string    `json:"url,omitempty"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+}
+
+//IsImage reports if the asset is an image
+func (a *Asset) IsImage() bool {
+	return imageTypes[a.ContentType]
+}
+
+//IsVideo reports if the asset is a video
+func (a *Asset) IsVideo() bool {
+	return videoTypes[a.ContentType]
+}
+
+//Options of the asset service
+type Options struct {
+	//PublicURL is prepended to the asset paths to build resolvable urls, ex: https://api.example.com
+	PublicURL string
+	//MaxImageSize and MaxVideoSize limit uploads, in bytes
+	MaxImageSize int64
+	MaxVideoSize int64
+	//MaxImagePixels limits the width times height of images, decoding them takes 4 bytes per pixel
+	MaxImagePixels int64
+	//ThumbnailSize is the maximum width and height of generated thumbnails
+	ThumbnailSize int
+}
+
+//DefaultOptions used for any zero value of the options
+var DefaultOptions = Options{
+	MaxImageSize:   10 << 20,
+	MaxVideoSize:   200 << 20,
+	MaxImagePixels: 25000000,
+	ThumbnailSize:  320,
+}
+
+//Service uploads and resolves assets on top of a blob store
+type Service struct {
+	blobs BlobStore
+	opts  Options
+}
+
+//New creates an asset service storing blobs in store
+func New(store BlobStore, opts Options) *Service {
+	if opts.MaxImageSize == 0 {
+		opts.MaxImageSize = DefaultOptions.MaxImageSize
+	}
+	if opts.MaxVideoSize == 0 {
+		opts.MaxVideoSize = DefaultOptions.MaxVideoSize
+	}
+	if opts.MaxImagePixels == 0 {
+		opts.MaxImagePixels = DefaultOptions.MaxImagePixels
+	}
+	if opts.ThumbnailSize == 0 {
+		opts.ThumbnailSize = DefaultOptions.ThumbnailSize
+	}
+	opts.PublicURL = strings.TrimSuffix(opts.PublicURL, "/")
+	return &Service{store, opts}
+}
+
+//Upload checks the content type and size of r, stores it and generates a thumbnail for images
+func (s *Service) Upload(ctx context.Context, filename string, r io.Reader) (*Asset, error) {
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("could not read upload. Error was %v", err)
+	}
+	a := &Asset{
+		ID:          newID(),
+		ContentType: http.DetectContentType(head),
+		Filename:    filename,
+		CreateTime:  time.Now().UTC(),
+	}
+	var limit int64
+	switch {
+	case a.IsImage():
+		limit = s.opts.MaxImageSize
+	case a.IsVideo():
+		limit = s.opts.MaxVideoSize
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedType, a.ContentType)
+	}
+	lr := &countingReader{r: io.LimitReader(br, limit+1)}
+	if a.IsImage() {
+		//images are small enough to be kept in memory for the thumbnail
+		var buf bytes.Buffer
+		if _, err = buf.ReadFrom(lr); err != nil {
+			return nil, fmt.Errorf("could not read upload. Error was %v", err)
+		}
+		if lr.n > limit {
+			return nil, fmt.Errorf("%w, images are limited to %d bytes", ErrTooLarge, limit)
+		}
+		thumb, err := thumbnail(buf.Bytes(), s.opts.ThumbnailSize, s.opts.MaxImagePixels)
+		if errors.Is(err, ErrTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w, could not decode image. Error was %v", ErrUnsupportedType, err)
+		}
+		if err = s.blobs.Put(ctx, thumbnailKey(a.ID), bytes.NewReader(thumb)); err != nil {
+			return nil, err
+		}
+		a.Thumbnail = true
+		if err = s.blobs.Put(ctx, originalKey(a.ID), &buf); err != nil {
+			return nil, err
+		}
+	} else {
+		if err = s.blobs.Put(ctx, originalKey(a.ID), lr); err != nil {
+			return nil, err
+		}
+		if lr.n > limit {
+			s.blobs.Delete(ctx, originalKey(a.ID))
+			return nil, fmt.Errorf("%w, videos are limited to %d bytes", ErrTooLarge, limit)
+		}
+	}
+	a.Size = lr.n
+	meta, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.blobs.Put(ctx, metaKey(a.ID), bytes.NewReader(meta)); err != nil {
+		return nil, err
+	}
+	s.withURLs(a)
+	return a, nil
+}
+
+//Resolve returns an asset by id with its resolvable urls, ErrNotFound if it does not exist
+func (s *Service) Resolve(ctx context.Context, id string) (*Asset, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	r, err := s.blobs.Get(ctx, metaKey(id))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var a Asset
+	if err = json.NewDecoder(r).Decode(&a); err != nil {
+		return nil, fmt.Errorf("could not read asset %s. Error was %v", id, err)
+	}
+	s.withURLs(&a)
+	return &a, nil
+}
+
+//Open returns the content of an asset, or of its thumbnail
+func (s *Service) Open(ctx context.Context, id string, thumbnail bool) (io.ReadCloser, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+	if thumbnail {
+		return s.blobs.Get(ctx, thumbnailKey(id))
+	}
+	return s.blobs.Get(ctx, originalKey(id))
+}
+
+func (s *Service) withURLs(a *Asset) {
+	a.URL = fmt.Sprintf("%s%s%s", s.opts.PublicURL, PathPrefix, a.ID)
+	if a.Thumbnail {
+		a.ThumbnailURL = a.URL + "/thumbnail"
+	}
+}
+
+func originalKey(id string) string  { return id + "/original" }
+func thumbnailKey(id string) string { return id + "/thumbnail" }
+func metaKey(id string) string      { return id + "/meta.json" }
+
+//newID returns a random 24 characters hex id, the same shape as the exercise ids
+func newID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func validID(id string) bool {
+	if len(id) != 24 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,93 @@
+// +build unit
+
+package assets
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//pngImage encodes a noisy image, so its size grows with its dimensions
+func pngImage(t *testing.T, w, h int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	rand.New(rand.NewSource(1)).Read(img.Pix)
+	var b bytes.Buffer
+	require.NoError(t, png.Encode(&b, img))
+	return b.Bytes()
+}
+
+//declaring rewrites the header of a png to declare the dimensions given, keeping its pixels
+func declaring(t *testing.T, b []byte, w, h uint32) []byte {
+	b = append([]byte(nil), b...)
+	//the IHDR chunk follows the 8 bytes signature: length, type, width, height and more, then its crc
+	require.Equal(t, "IHDR", string(b[12:16]))
+	binary.BigEndian.PutUint32(b[16:20], w)
+	binary.BigEndian.PutUint32(b[20:24], h)
+	binary.BigEndian.PutUint32(b[29:33], crc32.ChecksumIEEE(b[12:29]))
+	return b
+}
+
+func newService(t *testing.T, opts Options) *Service {
+	dir, err := ioutil.TempDir("", "assets")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	return New(store, opts)
+}
+
+func TestUpload(t *testing.T) {
+	ctx := context.Background()
+	s := newService(t, Options{PublicURL: "https://api.example.com/", MaxImageSize: 4 << 20})
+	t.Run("image", func(t *testing.T) {
+		a, err := s.Upload(ctx, "push-up.png", bytes.NewReader(pngImage(t, 640, 480)))
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", a.ContentType)
+		assert.True(t, a.IsImage())
+		assert.Equal(t, "https://api.example.com/v1/assets/"+a.ID, a.URL)
+		assert.Equal(t, a.URL+"/thumbnail", a.ThumbnailURL)
+
+		resolved, err := s.Resolve(ctx, a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, a.Size, resolved.Size)
+
+		r, err := s.Open(ctx, a.ID, true)
+		require.NoError(t, err)
+		defer r.Close()
+		thumb, _, err := image.Decode(r)
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 320, 240), thumb.Bounds())
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := s.Upload(ctx, "notes.txt", bytes.NewReader([]byte("not an image")))
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+	t.Run("too large", func(t *testing.T) {
+		_, err := s.Upload(ctx, "huge.png", bytes.NewReader(pngImage(t, 1200, 1200)))
+		assert.True(t, errors.Is(err, ErrTooLarge))
+	})
+	t.Run("too many pixels", func(t *testing.T) {
+		b := declaring(t, pngImage(t, 1, 1), 100000, 100000)
+		assert.Less(t, len(b), 1024)
+		_, err := s.Upload(ctx, "bomb.png", bytes.NewReader(b))
+		assert.True(t, errors.Is(err, ErrTooLarge), "got %v", err)
+	})
+	t.Run("unknown asset", func(t *testing.T) {
+		_, err := s.Resolve(ctx, "000000000000000000000000")
+		assert.True(t, errors.Is(err, ErrNotFound))
+		_, err = s.Resolve(ctx, "../../etc/passwd")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+}
@@ -0,0 +1,99 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//ErrNotFound is returned when a blob or asset does not exist
+var ErrNotFound = errors.New("not found")
+
+//BlobStore keeps the raw bytes of assets by key. It follows the semantics of S3-compatible
+//object stores (flat keys, whole-object writes) so a bucket backed store can replace FileStore
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+//FileStore is a BlobStore on the local filesystem, keys are relative paths under its root
+type FileStore struct {
+	root string
+}
+
+//NewFileStore creates a store rooted at dir, creating it if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create blob directory %s. Error was %v", dir, err)
+	}
+	return &FileStore{dir}, nil
+}
+
+//Put writes the blob into a temporary file and renames it, so readers never see partial blobs
+func (fs *FileStore) Put(ctx context.Context, key string, r io.Reader) error {
+	p, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return fmt.Errorf("could not create directory for %s. Error was %v", key, err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".upload-")
+	if err != nil {
+		return fmt.Errorf("could not create blob %s. Error was %v", key, err)
+	}
+	defer os.Remove(tmp.Name()) //no-op once renamed
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write blob %s. Error was %v", key, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("could not write blob %s. Error was %v", key, err)
+	}
+	if err = os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("could not store blob %s. Error was %v", key, err)
+	}
+	return nil
+}
+
+//Get opens a blob for reading
+func (fs *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := fs.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read blob %s. Error was %v", key, err)
+	}
+	return f, nil
+}
+
+//Delete removes a blob, deleting a missing blob is not an error
+func (fs *FileStore) Delete(ctx context.Context, key string) error {
+	p, err := fs.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not delete blob %s. Error was %v", key, err)
+	}
+	return nil
+}
+
+func (fs *FileStore) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if key == "" || strings.Contains(key, "..") || clean != "/"+key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(fs.root, filepath.FromSlash(key)), nil
+}
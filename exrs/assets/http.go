@@ -0,0 +1,130 @@
+package assets
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+//PathPrefix the asset routes are served under
+const PathPrefix = "/v1/assets/"
+
+//Handler serves the asset routes:
+//
+//	POST /v1/assets                  multipart upload of the "file" part
+//	GET  /v1/assets/{id}             the uploaded content
+//	GET  /v1/assets/{id}/thumbnail   the generated thumbnail, for images
+func Handler(s *Service, log *zap.Logger) http.Handler {
+	h := &handler{s, log.Sugar()}
+	mux := http.NewServeMux()
+	mux.HandleFunc(strings.TrimSuffix(PathPrefix, "/"), h.upload)
+	mux.HandleFunc(PathPrefix, h.download)
+	return mux
+}
+
+type handler struct {
+	*Service
+	log *zap.SugaredLogger
+}
+
+func (h *handler) upload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		httpError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	limit := h.opts.MaxVideoSize
+	if h.opts.MaxImageSize > limit {
+		limit = h.opts.MaxImageSize
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, limit+1<<20) //room for the multipart envelope
+	mr, err := r.MultipartReader()
+	if err != nil {
+		httpError(w, http.StatusBadRequest, "expected a multipart/form-data body")
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			httpError(w, http.StatusBadRequest, `missing "file" part`)
+			return
+		}
+		if err != nil {
+			httpError(w, http.StatusBadRequest, "malformed multipart body")
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		a, err := h.Upload(r.Context(), part.FileName(), part)
+		switch {
+		case errors.Is(err, ErrUnsupportedType):
+			httpError(w, http.StatusUnsupportedMediaType, err.Error())
+		case errors.Is(err, ErrTooLarge):
+			httpError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case err != nil:
+			h.log.Warnf("failed to upload asset %s. Error was %v", part.FileName(), err)
+			httpError(w, http.StatusInternalServerError, "could not store asset")
+		default:
+			h.log.Debugf("uploaded asset %v", a)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Location", a.URL)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(a)
+		}
+		return
+	}
+}
+
+func (h *handler) download(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		httpError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, PathPrefix)
+	thumb := strings.HasSuffix(id, "/thumbnail")
+	id = strings.TrimSuffix(id, "/thumbnail")
+	a, err := h.Resolve(r.Context(), id)
+	if err == nil && thumb && !a.Thumbnail {
+		err = ErrNotFound
+	}
+	if errors.Is(err, ErrNotFound) {
+		httpError(w, http.StatusNotFound, "asset not found")
+		return
+	}
+	if err != nil {
+		h.log.Warnf("failed to read asset %s. Error was %v", id, err)
+		httpError(w, http.StatusInternalServerError, "could not read asset")
+		return
+	}
+	content, err := h.Open(r.Context(), id, thumb)
+	if err != nil {
+		h.log.Warnf("failed to open asset %s. Error was %v", id, err)
+		httpError(w, http.StatusInternalServerError, "could not read asset")
+		return
+	}
+	defer content.Close()
+	contentType := a.ContentType
+	if thumb {
+		contentType = "image/jpeg"
+	}
+	w.Header().Set("Content-Type", contentType)
+	//assets are immutable, a new upload gets a new id
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	if rs, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", a.CreateTime, rs)
+		return
+	}
+	io.Copy(w, content)
+}
+
+func httpError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"error": msg, "code": code})
+}
@@ -0,0 +1,51 @@
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	//decoders of the accepted image types
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+)
+
+//thumbnail scales an image down to fit in a size x size box, encoded as jpeg. Images of more than
+//maxPixels fail with ErrTooLarge before being decoded, small files can declare huge dimensions
+func thumbnail(b []byte, size int, maxPixels int64) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if pixels := int64(config.Width) * int64(config.Height); pixels > maxPixels {
+		return nil, fmt.Errorf("%w, images are limited to %d pixels, got %dx%d", ErrTooLarge, maxPixels, config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w > size || h > size {
+		if w > h {
+			w, h = size, h*size/w
+		} else {
+			w, h = w*size/h, size
+		}
+	}
+	if w == 0 {
+		w = 1
+	}
+	if h == 0 {
+		h = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	var out bytes.Buffer
+	if err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+//FromCertificate maps a verified client certificate to the identity of the caller. The subject is the
+//common name, or the first URI, DNS name or email of the certificate when it has none
+func FromCertificate(c *x509.Certificate) Identity {
+	id := Identity{Subject: c.Subject.CommonName, Certificate: true}
+	for _, u := range c.URIs {
+		id.Names = append(id.Names, u.String())
+	}
+	id.Names = append(id.Names, c.DNSNames...)
+	id.Names = append(id.Names, c.EmailAddresses...)
+	if id.Subject == "" && len(id.Names) > 0 {
+		id.Subject = id.Names[0]
+	}
+	return id
+}
+
+//peerCertificate returns the verified certificate the caller presented over TLS, if any
+func peerCertificate(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return info.State.VerifiedChains[0][0], true
+}
+
+//CertificateInterceptor puts the identity of the verified client certificate of the call in its context.
+//Calls without one are left to the token interceptor. gateways are the subjects of the certificates
+//the gateway presents, it is never the caller: its calls act for the client certificate it forwards,
+//see ForwardCertificate, or are left to the token interceptor
+func CertificateInterceptor(gateways ...string) grpc.UnaryServerInterceptor {
+	trusted := make(map[string]bool, len(gateways))
+	for _, g := range gateways {
+		trusted[g] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if c, ok := peerCertificate(ctx); ok {
+			id := FromCertificate(c)
+			if !trusted[id.Subject] {
+				ctx = NewContext(ctx, id)
+			} else if client, ok := forwarded(ctx); ok {
+				ctx = NewContext(ctx, client)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,93 @@
+// +build unit
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestFromCertificate(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://exrs/ops")
+	testCases := []struct {
+		name     string
+		cert     *x509.Certificate
+		expected Identity
+	}{
+		{
+			name:     "common name",
+			cert:     &x509.Certificate{Subject: pkix.Name{CommonName: "ops"}, DNSNames: []string{"ops.example.com"}},
+			expected: Identity{Subject: "ops", Names: []string{"ops.example.com"}, Certificate: true},
+		},
+		{
+			name:     "uri first",
+			cert:     &x509.Certificate{URIs: []*url.URL{spiffe}, DNSNames: []string{"ops.example.com"}, EmailAddresses: []string{"ops@example.com"}},
+			expected: Identity{Subject: "spiffe://exrs/ops", Names: []string{"spiffe://exrs/ops", "ops.example.com", "ops@example.com"}, Certificate: true},
+		},
+		{
+			name:     "email",
+			cert:     &x509.Certificate{EmailAddresses: []string{"ops@example.com"}},
+			expected: Identity{Subject: "ops@example.com", Names: []string{"ops@example.com"}, Certificate: true},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, FromCertificate(tc.cert))
+		})
+	}
+}
+
+func TestCertificateInterceptor(t *testing.T) {
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "ops"}}}},
+	}}
+	testCases := []struct {
+		name    string
+		peer    *peer.Peer
+		subject string
+	}{
+		{"verified certificate", &peer.Peer{AuthInfo: verified}, "ops"},
+		{"tls without certificate", &peer.Peer{AuthInfo: credentials.TLSInfo{}}, Anonymous},
+		{"plain text", &peer.Peer{}, Anonymous},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var subject string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				subject = Subject(ctx)
+				return nil, nil
+			}
+			ctx := peer.NewContext(context.Background(), tc.peer)
+			_, err := CertificateInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.subject, subject)
+		})
+	}
+}
+
+//TestCertificateWithoutToken checks a verified certificate is enough when tokens are configured
+func TestCertificateWithoutToken(t *testing.T) {
+	ctx := metadata.NewIncomingContext(NewContext(context.Background(), Identity{Subject: "ops", Certificate: true}), metadata.MD{})
+	var subject string
+	_, err := UnaryServerInterceptor(Tokens{"s3cret": "other"})(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pbexrs.ExerciseService/ListExercises"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			subject = Subject(ctx)
+			return nil, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "ops", subject)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/metadata"
+)
+
+//metadata the gateway forwards the identity of the client certificate of the REST calls in
+const (
+	forwardedSubject = "x-exrs-client-subject"
+	forwardedNames   = "x-exrs-client-names"
+)
+
+//ForwardCertificate annotates the calls of the gateway with the identity of the verified client
+//certificate of the REST call, the gRPC server trusts it from the gateway only
+func ForwardCertificate(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	id := FromCertificate(r.TLS.VerifiedChains[0][0])
+	md := metadata.Pairs(forwardedSubject, id.Subject)
+	if len(id.Names) > 0 {
+		md.Set(forwardedNames, id.Names...)
+	}
+	return md
+}
+
+//HeaderMatcher forwards the headers of the REST calls like runtime.DefaultHeaderMatcher, dropping
+//the ones that would pass for an identity forwarded by the gateway
+func HeaderMatcher(key string) (string, bool) {
+	k, ok := runtime.DefaultHeaderMatcher(key)
+	switch strings.ToLower(k) {
+	case forwardedSubject, forwardedNames:
+		return "", false
+	}
+	return k, ok
+}
+
+//forwarded returns the identity the gateway forwards with the call, if any
+func forwarded(ctx context.Context) (Identity, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	subjects := md.Get(forwardedSubject)
+	if len(subjects) != 1 || subjects[0] == "" {
+		return Identity{}, false
+	}
+	return Identity{Subject: subjects[0], Names: md.Get(forwardedNames), Certificate: true}, true
+}
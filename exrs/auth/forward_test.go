@@ -0,0 +1,100 @@
+// +build unit
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func verifiedAs(cn string) credentials.TLSInfo {
+	return credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: cn}, DNSNames: []string{cn + ".example.com"}}}},
+	}}
+}
+
+func TestForwardCertificate(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v1/exercises", nil)
+	assert.Nil(t, ForwardCertificate(context.Background(), r), "plain text")
+	state := verifiedAs("alice").State
+	r.TLS = &state
+	assert.Equal(t, metadata.Pairs(forwardedSubject, "alice", forwardedNames, "alice.example.com"), ForwardCertificate(context.Background(), r))
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	testCases := []struct {
+		header   string
+		key      string
+		expected bool
+	}{
+		{"Grpc-Metadata-X-Exrs-Client-Subject", "", false},
+		{"Grpc-Metadata-X-Exrs-Client-Names", "", false},
+		{"Grpc-Metadata-Trace", "Trace", true},
+		{"Authorization", "grpcgateway-Authorization", true},
+		{"X-Exrs-Client-Subject", "", false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.header, func(t *testing.T) {
+			t.Parallel()
+			key, ok := HeaderMatcher(tc.header)
+			assert.Equal(t, tc.expected, ok)
+			assert.Equal(t, tc.key, key)
+		})
+	}
+}
+
+func TestCertificateInterceptorGateway(t *testing.T) {
+	forwarding := metadata.Pairs(forwardedSubject, "alice", forwardedNames, "alice.example.com")
+	testCases := []struct {
+		name    string
+		peer    string
+		md      metadata.MD
+		subject string
+	}{
+		{"gateway forwarding a client", "gateway", forwarding, "alice"},
+		{"gateway without client certificate", "gateway", metadata.MD{}, Anonymous},
+		{"client forging a forwarded identity", "mallory", forwarding, "mallory"},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var subject string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				subject = Subject(ctx)
+				return nil, nil
+			}
+			ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: verifiedAs(tc.peer)})
+			ctx = metadata.NewIncomingContext(ctx, tc.md)
+			_, err := CertificateInterceptor("gateway")(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.subject, subject)
+		})
+	}
+}
+
+//TestGatewayWithoutToken checks the certificate of the gateway is not enough when tokens are configured
+func TestGatewayWithoutToken(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: verifiedAs("gateway")})
+	ctx = metadata.NewIncomingContext(ctx, metadata.MD{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/pbexrs.ExerciseService/ListExercises"}
+	_, err := CertificateInterceptor("gateway")(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return UnaryServerInterceptor(Tokens{"s3cret": "ops"})(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,38 @@
+//Package auth carries the identity of the caller through the request context
+package auth
+
+import "context"
+
+//Anonymous is the subject of requests without identity
+const Anonymous = "anonymous"
+
+//Identity of the caller of a request
+type Identity struct {
+	//Subject uniquely names the caller, ex: a user name or a certificate common name
+	Subject string
+	//Names are the alternative names of the certificate of the caller: DNS names, URIs and emails
+	Names []string
+	//Certificate tells the caller was authenticated by a client certificate rather than a token
+	Certificate bool
+}
+
+type identityKey struct{}
+
+//NewContext returns a copy of ctx carrying the identity
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+//FromContext returns the identity carried by ctx, if any
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+//Subject returns the subject of the identity carried by ctx, Anonymous if there is none
+func Subject(ctx context.Context) string {
+	if id, ok := FromContext(ctx); ok && id.Subject != "" {
+		return id.Subject
+	}
+	return Anonymous
+}
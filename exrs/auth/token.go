@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//healthService is reachable without a token, orchestrators probing it hold none
+const healthService = "/grpc.health.v1.Health/"
+
+//Tokens maps the bearer tokens callers present to their subject
+type Tokens map[string]string
+
+//subject returns the subject of token, comparing in constant time to not leak the tokens
+func (t Tokens) subject(token string) (string, bool) {
+	found, subject := 0, ""
+	for k, s := range t {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(token)) == 1 {
+			found, subject = 1, s
+		}
+	}
+	return subject, found == 1
+}
+
+//Bearer returns the token of the "authorization: Bearer <token>" metadata, the gateway forwards
+//the Authorization header as such
+func Bearer(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			return strings.TrimSpace(v[7:])
+		}
+	}
+	return ""
+}
+
+//UnaryServerInterceptor puts the identity of the token of the call in its context. Without tokens
+//every call is anonymous, otherwise every call but health checks needs a known token or a client
+//certificate, see CertificateInterceptor
+func UnaryServerInterceptor(tokens Tokens) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(tokens) == 0 || strings.HasPrefix(info.FullMethod, healthService) {
+			return handler(ctx, req)
+		}
+		//a verified client certificate, or the one the gateway forwards, already authenticated the caller
+		if id, ok := FromContext(ctx); ok && id.Certificate {
+			return handler(ctx, req)
+		}
+		token := Bearer(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		subject, ok := tokens.subject(token)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		return handler(NewContext(ctx, Identity{Subject: subject}), req)
+	}
+}
@@ -0,0 +1,51 @@
+// +build unit
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tokens        Tokens
+		method        string
+		authorization string
+		code          codes.Code
+		subject       string
+	}{
+		{"no tokens configured", nil, "/pbexrs.ExerciseService/ListExercises", "", codes.OK, Anonymous},
+		{"valid token", Tokens{"s3cret": "ops"}, "/pbexrs.ExerciseService/ListExercises", "Bearer s3cret", codes.OK, "ops"},
+		{"case insensitive scheme", Tokens{"s3cret": "ops"}, "/pbexrs.ExerciseService/ListExercises", "bearer s3cret", codes.OK, "ops"},
+		{"missing token", Tokens{"s3cret": "ops"}, "/pbexrs.ExerciseService/ListExercises", "", codes.Unauthenticated, ""},
+		{"unknown token", Tokens{"s3cret": "ops"}, "/pbexrs.ExerciseService/ListExercises", "Bearer guess", codes.Unauthenticated, ""},
+		{"other scheme", Tokens{"s3cret": "ops"}, "/pbexrs.ExerciseService/ListExercises", "Basic s3cret", codes.Unauthenticated, ""},
+		{"health checks", Tokens{"s3cret": "ops"}, "/grpc.health.v1.Health/Check", "", codes.OK, Anonymous},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.authorization))
+			}
+			var subject string
+			_, err := UnaryServerInterceptor(tc.tokens)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					subject = Subject(ctx)
+					return nil, nil
+				})
+			assert.Equal(t, tc.code, status.Code(err))
+			assert.Equal(t, tc.subject, subject)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+//Package certs serves TLS with certificate files reloaded when they change on disk, so renewed
+//certificates and client CA bundles are picked up without a restart
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+//ReloadInterval between two checks of the files
+const ReloadInterval = 10 * time.Second
+
+//Reloader holds a key pair and an optional CA bundle, reloaded when their files change
+type Reloader struct {
+	certFile, keyFile, caFile string
+	//OnError is told about failed reloads, the previous files keep being served
+	OnError func(error)
+
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	pool  *x509.CertPool
+	stamp string
+}
+
+//NewReloader loads the key pair, and the CA bundle when caFile is not empty
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile, OnError: func(error) {}}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+//files the reloader watches
+func (r *Reloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.caFile != "" {
+		files = append(files, r.caFile)
+	}
+	return files
+}
+
+//stamps tells the files apart from their previous version by modification time and size
+func (r *Reloader) stamps() (string, error) {
+	var b strings.Builder
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return "", fmt.Errorf("could not stat %v. Error was %v", f, err)
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", f, fi.ModTime().UnixNano(), fi.Size())
+	}
+	return b.String(), nil
+}
+
+//Reload reads the files again if they changed since the last load
+func (r *Reloader) Reload() error {
+	stamp, err := r.stamps()
+	if err != nil {
+		return err
+	}
+	r.mu.RLock()
+	unchanged := stamp == r.stamp
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load certificate %v. Error was %v", r.certFile, err)
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		if pool, err = LoadPool(r.caFile); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	r.cert, r.pool, r.stamp = &cert, pool, stamp
+	r.mu.Unlock()
+	return nil
+}
+
+//Run reloads the files every interval until ctx is done
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := r.Reload(); err != nil {
+				r.OnError(err)
+			}
+		}
+	}
+}
+
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, r.pool
+}
+
+//ServerConfig serves the current certificate. With a CA bundle clients are asked for a
+//certificate verified by it, required unless optional is set
+func (r *Reloader) ServerConfig(optional bool) *tls.Config {
+	base := &tls.Config{MinVersion: tls.VersionTLS12, NextProtos: []string{"h2", "http/1.1"}}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cert, pool := r.current()
+		c := base.Clone()
+		c.GetConfigForClient = nil
+		c.Certificates = []tls.Certificate{*cert}
+		if pool != nil {
+			c.ClientCAs = pool
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+			if optional {
+				c.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+		}
+		return c, nil
+	}
+	return base
+}
+
+//ClientConfig presents the current certificate to servers verified by roots, the system roots when nil
+func (r *Reloader) ClientConfig(serverName string, roots *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		RootCAs:    roots,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+	}
+}
+
+//LoadPool reads a bundle of PEM certificates
+func LoadPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA %v. Error was %v", file, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in CA %v", file)
+	}
+	return pool, nil
+}
+
+//LoadLeaf reads the first PEM certificate of file, the leaf of a chain
+func LoadLeaf(file string) (*x509.Certificate, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read certificate %v. Error was %v", file, err)
+	}
+	for {
+		var block *pem.Block
+		if block, b = pem.Decode(b); block == nil {
+			return nil, fmt.Errorf("no certificate found in %v", file)
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// +build unit
+
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+//newAuthority creates a self signed CA
+func newAuthority(t *testing.T) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &authority{cert, key}
+}
+
+//issue writes a certificate for cn signed by the authority and its key to dir
+func (a *authority) issue(t *testing.T, dir, cn string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.cert, &key.PublicKey, a.key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	certFile, keyFile := filepath.Join(dir, cn+".pem"), filepath.Join(dir, cn+".key")
+	assert.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func (a *authority) write(t *testing.T, file string) {
+	assert.NoError(t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.cert.Raw}), 0600))
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "certs")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+//handshake connects a client presenting clientCert, when given, to a server with config
+func handshake(t *testing.T, config *tls.Config, roots *x509.CertPool, clientCert *tls.Certificate) (*x509.Certificate, error) {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	assert.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		c, err := lis.Accept()
+		if err == nil {
+			c.(*tls.Conn).Handshake()
+			c.Close()
+		}
+	}()
+	client := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+	if clientCert != nil {
+		client.Certificates = []tls.Certificate{*clientCert}
+	}
+	conn, err := tls.Dial("tcp", lis.Addr().String(), client)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	//the server rejects a missing certificate after the client handshake completed
+	if _, err := conn.Read(make([]byte, 1)); err != nil && err.Error() != "EOF" {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestReload(t *testing.T) {
+	dir := tempDir(t)
+	ca := newAuthority(t)
+	certFile, keyFile := ca.issue(t, dir, "server", 2)
+	r, err := NewReloader(certFile, keyFile, "")
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	served, err := handshake(t, r.ServerConfig(false), roots, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), served.SerialNumber.Int64())
+
+	//renew the certificate in place, a later modification time tells it changed
+	ca.issue(t, dir, "server", 3)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, later, later))
+	assert.NoError(t, r.Reload())
+	served, err = handshake(t, r.ServerConfig(false), roots, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), served.SerialNumber.Int64())
+
+	//a broken file keeps the previous certificate
+	assert.NoError(t, ioutil.WriteFile(certFile, []byte("garbage"), 0600))
+	assert.Error(t, r.Reload())
+	served, err = handshake(t, r.ServerConfig(false), roots, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), served.SerialNumber.Int64())
+}
+
+func TestClientAuth(t *testing.T) {
+	dir := tempDir(t)
+	ca, other := newAuthority(t), newAuthority(t)
+	caFile := filepath.Join(dir, "ca.pem")
+	ca.write(t, caFile)
+	certFile, keyFile := ca.issue(t, dir, "server", 2)
+	clientFile, clientKey := ca.issue(t, dir, "ops", 3)
+	strangerFile, strangerKey := other.issue(t, dir, "stranger", 4)
+	r, err := NewReloader(certFile, keyFile, caFile)
+	assert.NoError(t, err)
+	client, err := NewReloader(clientFile, clientKey, "")
+	assert.NoError(t, err)
+	stranger, err := tls.LoadX509KeyPair(strangerFile, strangerKey)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	testCases := []struct {
+		name     string
+		optional bool
+		cert     *tls.Certificate
+		err      bool
+	}{
+		{"verified client", false, client.cert, false},
+		{"no certificate", false, nil, true},
+		{"unknown authority", false, &stranger, true},
+		{"optional without certificate", true, nil, false},
+		{"optional unknown authority", true, &stranger, true},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := handshake(t, r.ServerConfig(tc.optional), roots, tc.cert)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,480 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/maxvw8/exercise_lib/exrs/transfer"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+//ErrUsage is returned when the command line is invalid, the usage was printed
+var ErrUsage = errors.New("invalid usage")
+
+//session is what a command runs with
+type session struct {
+	client pbexrs.ExerciseServiceClient
+	print  *printer
+	in     io.Reader
+	errOut io.Writer
+}
+
+//action runs a command with its positional arguments
+type action func(ctx context.Context, s *session, args []string) error
+
+//command registers its flags and returns what to run once they are parsed
+type command struct {
+	args  string
+	help  string
+	flags func(fs *flag.FlagSet) action
+}
+
+var commands = map[string]command{
+	"get":        {"<id>", "print an exercise", getCommand},
+	"list":       {"", "list exercises, a page at a time unless -all", listCommand},
+	"create":     {"", "create an exercise from -f and the field flags", createCommand},
+	"update":     {"<id>", "update the fields of an exercise given by -f and the field flags", updateCommand},
+	"delete":     {"<id>", "delete an exercise", deleteCommand},
+	"duplicates": {"", "list the groups of exercises that are likely the same", duplicatesCommand},
+	"merge":      {"<survivor-id>", "merge the -duplicate exercises into the survivor, deleting them", mergeCommand},
+	"recommend":  {"", "recommend exercises for the -muscle-group given, with the -equipment at hand", recommendCommand},
+	"import":     {"", "create the taxonomy and the exercises of an export file", importCommand},
+	"export":     {"", "write the taxonomy and every exercise to a file", exportCommand},
+}
+
+//Usage lists the commands
+func Usage(w io.Writer, name string) {
+	fmt.Fprintf(w, "usage: %s <command> [flags] [args]\n\ncommands:\n", name)
+	names := make([]string, 0, len(commands))
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", n, commands[n].help)
+	}
+	fmt.Fprintf(w, "\nrun %s <command> -h for the flags of a command\n", name)
+}
+
+//Run parses args, starting with the command name, connects to the service and runs the command
+func Run(ctx context.Context, name string, args []string, in io.Reader, out, errOut io.Writer) error {
+	if len(args) == 0 {
+		Usage(errOut, name)
+		return ErrUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(errOut, "unknown command %q\n\n", args[0])
+		Usage(errOut, name)
+		return ErrUsage
+	}
+	fs := flag.NewFlagSet(name+" "+args[0], flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	var o Options
+	o.RegisterFlags(fs)
+	run := cmd.flags(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(errOut, "usage: %s %s [flags] %s\n\n%s\n\nflags:\n", name, args[0], cmd.args, cmd.help)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return ErrUsage
+	}
+	if want := len(strings.Fields(cmd.args)); fs.NArg() != want {
+		fs.Usage()
+		return ErrUsage
+	}
+	p, err := newPrinter(out, o.Output)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	conn, err := Dial(ctx, o)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return run(ctx, &session{pbexrs.NewExerciseServiceClient(conn), p, in, errOut}, fs.Args())
+}
+
+func getCommand(fs *flag.FlagSet) action {
+	angle := fs.String("video-angle", "", "only show the videos recorded from this angle")
+	return func(ctx context.Context, s *session, args []string) error {
+		req := &pbexrs.GetExerciseRequest{Id: args[0]}
+		a, err := parseEnum("video-angle", *angle, pbexrs.CameraAngle_value)
+		if err != nil {
+			return err
+		}
+		req.VideoAngle = pbexrs.CameraAngle(a)
+		e, err := s.client.GetExercise(ctx, req)
+		if err != nil {
+			return err
+		}
+		return s.print.exercises(e, e)
+	}
+}
+
+func listCommand(fs *flag.FlagSet) action {
+	difficulty := fs.String("difficulty", "", "only list exercises of this difficulty")
+	updatedAfter := fs.String("updated-after", "", "only list exercises updated after this RFC 3339 time, sorted by update time")
+	angle := fs.String("video-angle", "", "only show the videos recorded from this angle")
+	size := fs.Int("page-size", 50, "exercises per page")
+	token := fs.String("page-token", "", "page to list, as printed by the previous page")
+	all := fs.Bool("all", false, "list every page")
+	return func(ctx context.Context, s *session, _ []string) error {
+		req := &pbexrs.ListExercisesRequest{PageSize: int32(*size), PageToken: *token}
+		d, err := parseEnum("difficulty", *difficulty, pbexrs.Difficulty_value)
+		if err != nil {
+			return err
+		}
+		a, err := parseEnum("video-angle", *angle, pbexrs.CameraAngle_value)
+		if err != nil {
+			return err
+		}
+		req.Difficulty, req.VideoAngle = pbexrs.Difficulty(d), pbexrs.CameraAngle(a)
+		if *updatedAfter != "" {
+			t, err := time.Parse(time.RFC3339, *updatedAfter)
+			if err != nil {
+				return fmt.Errorf("invalid -updated-after. Error was %v", err)
+			}
+			if req.UpdatedAfter, err = ptypes.TimestampProto(t); err != nil {
+				return err
+			}
+		}
+		resp, err := s.client.ListExercises(ctx, req)
+		if err != nil {
+			return err
+		}
+		for *all && resp.NextPageToken != "" {
+			req.PageToken = resp.NextPageToken
+			next, err := s.client.ListExercises(ctx, req)
+			if err != nil {
+				return err
+			}
+			resp.Exercises, resp.NextPageToken = append(resp.Exercises, next.Exercises...), next.NextPageToken
+		}
+		if err := s.print.exercises(resp, resp.Exercises...); err != nil {
+			return err
+		}
+		if s.print.format == OutputTable && resp.NextPageToken != "" {
+			fmt.Fprintf(s.errOut, "more exercises with -page-token %s\n", resp.NextPageToken)
+		}
+		return nil
+	}
+}
+
+func createCommand(fs *flag.FlagSet) action {
+	var f exerciseFlags
+	f.register(fs)
+	return func(ctx context.Context, s *session, _ []string) error {
+		e, err := f.exercise(fs, s.in)
+		if err != nil {
+			return err
+		}
+		e, err = s.client.CreateExercise(ctx, &pbexrs.CreateExerciseRequest{Exercise: e})
+		if err != nil {
+			return err
+		}
+		return s.print.exercises(e, e)
+	}
+}
+
+func updateCommand(fs *flag.FlagSet) action {
+	var f exerciseFlags
+	f.register(fs)
+	return func(ctx context.Context, s *session, args []string) error {
+		e, err := f.exercise(fs, s.in)
+		if err != nil {
+			return err
+		}
+		e, err = s.client.UpdateExercise(ctx, &pbexrs.UpdateRequest{Id: args[0], Exercise: e})
+		if err != nil {
+			return err
+		}
+		return s.print.exercises(e, e)
+	}
+}
+
+func deleteCommand(fs *flag.FlagSet) action {
+	policy := fs.String("policy", "restrict", "what happens to the entities referencing the exercise: restrict, cascade or nullify")
+	return func(ctx context.Context, s *session, args []string) error {
+		p, ok := pbexrs.DeletePolicy_value[strings.ToUpper(*policy)]
+		if !ok || p == 0 {
+			return fmt.Errorf("unknown policy %q, expected restrict, cascade or nullify", *policy)
+		}
+		resp, err := s.client.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: args[0], Policy: pbexrs.DeletePolicy(p)})
+		if err != nil {
+			return err
+		}
+		if s.print.format == OutputTable {
+			_, err = fmt.Fprintf(s.print.w, "deleted %s\n", args[0])
+			return err
+		}
+		return s.print.message(resp)
+	}
+}
+
+func duplicatesCommand(fs *flag.FlagSet) action {
+	minScore := fs.Int("min-score", 0, "only group exercises scoring at least this percentage, 80 if unset")
+	return func(ctx context.Context, s *session, _ []string) error {
+		resp, err := s.client.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{MinScore: int32(*minScore)})
+		if err != nil {
+			return err
+		}
+		if s.print.format != OutputTable {
+			return s.print.message(resp)
+		}
+		for i, g := range resp.Groups {
+			if i > 0 {
+				fmt.Fprintln(s.print.w)
+			}
+			fmt.Fprintf(s.print.w, "score %.2f: %s\n", g.Score, strings.Join(g.Reasons, "; "))
+			if err := s.print.exercises(g, g.Exercises...); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func mergeCommand(fs *flag.FlagSet) action {
+	var duplicates stringList
+	fs.Var(&duplicates, "duplicate", "id of an exercise to merge into the survivor, repeat for several")
+	return func(ctx context.Context, s *session, args []string) error {
+		if len(duplicates) == 0 {
+			return errors.New("-duplicate is required")
+		}
+		e, err := s.client.MergeExercises(ctx, &pbexrs.MergeExercisesRequest{SurvivorId: args[0], DuplicateIds: duplicates})
+		if err != nil {
+			return err
+		}
+		return s.print.exercises(e, e)
+	}
+}
+
+func recommendCommand(fs *flag.FlagSet) action {
+	var groups, equipment, exclude stringList
+	fs.Var(&groups, "muscle-group", "muscle group to work, repeat for several")
+	fs.Var(&equipment, "equipment", "equipment at hand, repeat for several, any equipment if unset")
+	fs.Var(&exclude, "exclude", "id of an exercise not to recommend, repeat for several")
+	difficulty := fs.String("difficulty", "", "difficulty wanted, harder exercises are left out")
+	limit := fs.Int("limit", 0, "exercises to recommend, 5 if unset")
+	return func(ctx context.Context, s *session, _ []string) error {
+		if len(groups) == 0 {
+			return errors.New("-muscle-group is required")
+		}
+		d, err := parseEnum("difficulty", *difficulty, pbexrs.Difficulty_value)
+		if err != nil {
+			return err
+		}
+		resp, err := s.client.RecommendExercises(ctx, &pbexrs.RecommendExercisesRequest{MuscleGroups: groups, Equipment: equipment,
+			Difficulty: pbexrs.Difficulty(d), ExcludeIds: exclude, Limit: int32(*limit)})
+		if err != nil {
+			return err
+		}
+		if s.print.format != OutputTable {
+			return s.print.message(resp)
+		}
+		return s.print.recommendations(resp.Recommendations)
+	}
+}
+
+func importCommand(fs *flag.FlagSet) action {
+	file := fs.String("f", "-", "export file to import, - for standard input")
+	return func(ctx context.Context, s *session, _ []string) error {
+		r, closer, err := open(*file, s.in)
+		if err != nil {
+			return err
+		}
+		defer closer()
+		n, err := transfer.Import(ctx, transfer.Remote(s.client), r)
+		fmt.Fprintf(s.errOut, "imported %d exercises\n", n)
+		return err
+	}
+}
+
+func exportCommand(fs *flag.FlagSet) action {
+	file := fs.String("f", "-", "file to write, - for standard output")
+	return func(ctx context.Context, s *session, _ []string) error {
+		w := s.print.w
+		if *file != "-" {
+			f, err := os.Create(*file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		n, err := transfer.Export(ctx, transfer.Remote(s.client), w)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.errOut, "exported %d exercises\n", n)
+		return nil
+	}
+}
+
+//open returns the file at path, in for -
+func open(path string, in io.Reader) (io.Reader, func() error, error) {
+	if path == "-" {
+		return in, func() error { return nil }, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+//parseEnum returns the value of an enum by name, in any case. Empty is the unspecified value
+func parseEnum(flag, name string, values map[string]int32) (int32, error) {
+	if name == "" {
+		return 0, nil
+	}
+	v, ok := values[strings.ToUpper(name)]
+	if !ok || v == 0 {
+		var names []string
+		for n, v := range values {
+			if v != 0 {
+				names = append(names, n)
+			}
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("invalid -%s %q, use one of %s", flag, name, strings.Join(names, ", "))
+	}
+	return v, nil
+}
+
+//stringList is a flag that can be repeated
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+//exerciseFlags build the exercise to create or update
+type exerciseFlags struct {
+	file                                      string
+	name, description                         string
+	kind, difficulty, mechanics, force        string
+	categories, muscles, muscleGroups, images stringList
+	instructions, tips, mistakes              stringList
+}
+
+func (f *exerciseFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "f", "", "json file of the exercise, - for standard input, the flags override its fields")
+	fs.StringVar(&f.name, "name", "", "name")
+	fs.StringVar(&f.description, "description", "", "description")
+	fs.StringVar(&f.kind, "kind", "", "kind: anaerobic, aerobic or flexibility")
+	fs.StringVar(&f.difficulty, "difficulty", "", "difficulty")
+	fs.StringVar(&f.mechanics, "mechanics", "", "mechanics")
+	fs.StringVar(&f.force, "force", "", "force")
+	fs.Var(&f.categories, "category", "category, repeat for several")
+	fs.Var(&f.muscles, "muscle", "muscle, repeat for several")
+	fs.Var(&f.muscleGroups, "muscle-group", "muscle group, repeat for several")
+	fs.Var(&f.images, "image", "image url, repeat for several")
+	fs.Var(&f.instructions, "instruction", "instruction step, repeat for several")
+	fs.Var(&f.tips, "tip", "tip, repeat for several")
+	fs.Var(&f.mistakes, "common-mistake", "common mistake, repeat for several")
+}
+
+//exercise reads the file then sets the fields of the flags given on the command line
+func (f *exerciseFlags) exercise(fs *flag.FlagSet, in io.Reader) (*pbexrs.Exercise, error) {
+	e := &pbexrs.Exercise{}
+	if f.file != "" {
+		r, closer, err := open(f.file, in)
+		if err != nil {
+			return nil, err
+		}
+		defer closer()
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if err := protojson.Unmarshal(b, e); err != nil {
+			return nil, fmt.Errorf("invalid exercise in %v. Error was %v", f.file, err)
+		}
+	}
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		if err != nil {
+			return
+		}
+		var v int32
+		switch fl.Name {
+		case "name":
+			e.Name = f.name
+		case "description":
+			e.Description = f.description
+		case "kind":
+			v, err = parseEnum(fl.Name, f.kind, pbexrs.Kind_value)
+			e.Kind = pbexrs.Kind(v)
+		case "difficulty":
+			v, err = parseEnum(fl.Name, f.difficulty, pbexrs.Difficulty_value)
+			e.Difficulty = pbexrs.Difficulty(v)
+		case "mechanics":
+			v, err = parseEnum(fl.Name, f.mechanics, pbexrs.Mechanics_value)
+			e.Mechanics = pbexrs.Mechanics(v)
+		case "force":
+			v, err = parseEnum(fl.Name, f.force, pbexrs.Force_value)
+			e.Force = pbexrs.Force(v)
+		case "category":
+			e.Categories = f.categories
+		case "muscle":
+			e.Muscles = f.muscles
+		case "muscle-group":
+			e.MuscleGroups = f.muscleGroups
+		case "image":
+			e.Images = f.images
+		case "instruction":
+			e.Instructions = f.instructions
+		case "tip":
+			e.Tips = f.tips
+		case "common-mistake":
+			e.CommonMistakes = f.mistakes
+		}
+	})
+	return e, err
+}
+
+//Describe renders err for the terminal, listing the fields rejected by the service or the references
+//preventing a delete
+func Describe(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", st.Code(), st.Message())
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fmt.Fprintf(&b, "\n  %s: %s", v.Field, v.Description)
+			}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.Violations {
+				fmt.Fprintf(&b, "\n  %s: %s", v.Subject, v.Description)
+			}
+		}
+	}
+	return b.String()
+}
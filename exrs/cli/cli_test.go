@@ -0,0 +1,194 @@
+// +build unit
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+//fakeService pages through exercises and records what it was sent
+type fakeService struct {
+	pbexrs.UnimplementedExerciseServiceServer
+	exercises []*pbexrs.Exercise
+	created   *pbexrs.Exercise
+	merge     *pbexrs.MergeExercisesRequest
+	recommend *pbexrs.RecommendExercisesRequest
+	subject   string
+}
+
+func (f *fakeService) ListExercises(ctx context.Context, req *pbexrs.ListExercisesRequest) (*pbexrs.ListExercisesResponse, error) {
+	f.subject = auth.Subject(ctx)
+	var offset int
+	fmt.Sscan(req.PageToken, &offset)
+	end := offset + int(req.PageSize)
+	if end >= len(f.exercises) {
+		return &pbexrs.ListExercisesResponse{Exercises: f.exercises[offset:]}, nil
+	}
+	return &pbexrs.ListExercisesResponse{Exercises: f.exercises[offset:end], NextPageToken: fmt.Sprint(end)}, nil
+}
+
+func (f *fakeService) GetExercise(ctx context.Context, req *pbexrs.GetExerciseRequest) (*pbexrs.Exercise, error) {
+	return &pbexrs.Exercise{Id: req.Id, Name: "push up", Kind: pbexrs.Kind_ANAEROBIC, Categories: []string{"chest", "arms"}}, nil
+}
+
+func (f *fakeService) CreateExercise(ctx context.Context, req *pbexrs.CreateExerciseRequest) (*pbexrs.Exercise, error) {
+	f.created = req.Exercise
+	return req.Exercise, nil
+}
+
+func (f *fakeService) FindDuplicateExercises(ctx context.Context, req *pbexrs.FindDuplicateExercisesRequest) (*pbexrs.FindDuplicateExercisesResponse, error) {
+	return &pbexrs.FindDuplicateExercisesResponse{Groups: []*pbexrs.DuplicateGroup{{
+		Exercises: []*pbexrs.Exercise{{Id: "1", Name: "Push Up"}, {Id: "2", Name: "Pushups"}},
+		Score:     1,
+		Reasons:   []string{`"Push Up" and "Pushups" have the same normalized name "pushup"`},
+	}}}, nil
+}
+
+func (f *fakeService) MergeExercises(ctx context.Context, req *pbexrs.MergeExercisesRequest) (*pbexrs.Exercise, error) {
+	f.merge = req
+	return &pbexrs.Exercise{Id: req.SurvivorId, Name: "Push Up"}, nil
+}
+
+func (f *fakeService) RecommendExercises(ctx context.Context, req *pbexrs.RecommendExercisesRequest) (*pbexrs.RecommendExercisesResponse, error) {
+	f.recommend = req
+	return &pbexrs.RecommendExercisesResponse{Recommendations: []*pbexrs.Recommendation{{
+		Exercise: &pbexrs.Exercise{Id: "1", Name: "Dumbbell Row"},
+		Score:    0.9,
+		Reasons:  []string{"works back, biceps", "uses only available equipment: dumbbell"},
+	}}}, nil
+}
+
+//serve starts the fake on a local port, requiring the token
+func serve(t *testing.T, f *fakeService) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := grpc.NewServer(grpc.UnaryInterceptor(auth.UnaryServerInterceptor(auth.Tokens{"s3cret": "ops"})))
+	pbexrs.RegisterExerciseServiceServer(s, f)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func run(endpoint string, args ...string) (string, string, error) {
+	var out, errOut bytes.Buffer
+	args = append(args[:1], append([]string{"-endpoint", endpoint, "-token", "s3cret"}, args[1:]...)...)
+	err := Run(context.Background(), "exrsctl", args, strings.NewReader(""), &out, &errOut)
+	return out.String(), errOut.String(), err
+}
+
+func TestList(t *testing.T) {
+	f := &fakeService{exercises: []*pbexrs.Exercise{{Id: "1", Name: "push up"}, {Id: "2", Name: "sit up"}, {Id: "3", Name: "squat"}}}
+	endpoint := serve(t, f)
+
+	out, errOut, err := run(endpoint, "list", "-page-size", "2")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "push up")
+	assert.NotContains(t, out, "squat")
+	assert.Contains(t, errOut, "-page-token 2")
+	assert.Equal(t, "ops", f.subject)
+
+	out, _, err = run(endpoint, "list", "-page-size", "2", "-all", "-output", "json")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"name": "squat"`)
+	assert.NotContains(t, out, "nextPageToken")
+
+	out, _, err = run(endpoint, "list", "-page-size", "2", "-page-token", "2", "-output", "yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "exercises:\n- id: \"3\"\n  name: squat\n", out)
+}
+
+func TestGet(t *testing.T) {
+	endpoint := serve(t, &fakeService{})
+	out, _, err := run(endpoint, "get", "5f0c6bd1a2b3c4d5e6f70001")
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Regexp(t, `^ID\s+NAME\s+KIND\s+DIFFICULTY\s+CATEGORIES`, lines[0])
+		assert.Regexp(t, `^5f0c6bd1a2b3c4d5e6f70001\s+push up\s+ANAEROBIC\s+chest,arms`, lines[1])
+	}
+}
+
+func TestCreate(t *testing.T) {
+	f := &fakeService{}
+	endpoint := serve(t, f)
+	_, _, err := run(endpoint, "create", "-name", "push up", "-kind", "anaerobic", "-category", "chest", "-category", "arms", "-difficulty", "beginner")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pbexrs.Exercise{
+		Name:       "push up",
+		Kind:       pbexrs.Kind_ANAEROBIC,
+		Categories: []string{"chest", "arms"},
+		Difficulty: pbexrs.Difficulty_BEGINNER,
+	}, f.created), "created %v", f.created)
+
+	_, _, err = run(endpoint, "create", "-kind", "walking")
+	assert.EqualError(t, err, `invalid -kind "walking", use one of AEROBIC, ANAEROBIC, FLEXIBILITY`)
+}
+
+func TestDuplicates(t *testing.T) {
+	f := &fakeService{}
+	endpoint := serve(t, f)
+	out, _, err := run(endpoint, "duplicates")
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(t, lines, 4) {
+		assert.Equal(t, `score 1.00: "Push Up" and "Pushups" have the same normalized name "pushup"`, lines[0])
+		assert.Regexp(t, `^2\s+Pushups`, lines[3])
+	}
+
+	_, _, err = run(endpoint, "merge", "-duplicate", "2", "-duplicate", "3", "1")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pbexrs.MergeExercisesRequest{SurvivorId: "1", DuplicateIds: []string{"2", "3"}}, f.merge), "merged %v", f.merge)
+	_, _, err = run(endpoint, "merge", "1")
+	assert.EqualError(t, err, "-duplicate is required")
+}
+
+func TestRecommend(t *testing.T) {
+	f := &fakeService{}
+	endpoint := serve(t, f)
+	out, _, err := run(endpoint, "recommend", "-muscle-group", "back", "-muscle-group", "biceps", "-equipment", "dumbbell",
+		"-difficulty", "beginner", "-exclude", "2", "-limit", "3")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&pbexrs.RecommendExercisesRequest{MuscleGroups: []string{"back", "biceps"}, Equipment: []string{"dumbbell"},
+		Difficulty: pbexrs.Difficulty_BEGINNER, ExcludeIds: []string{"2"}, Limit: 3}, f.recommend), "recommended %v", f.recommend)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Regexp(t, `^1\s+Dumbbell Row\s+0.90\s+works back, biceps; uses only available equipment: dumbbell$`, lines[1])
+	}
+
+	_, _, err = run(endpoint, "recommend")
+	assert.EqualError(t, err, "-muscle-group is required")
+}
+
+func TestUsage(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"unknown command", []string{"frobnicate"}},
+		{"missing id", []string{"get"}},
+		{"extra args", []string{"list", "more"}},
+		{"unknown flag", []string{"list", "-nope"}},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var errOut bytes.Buffer
+			err := Run(context.Background(), "exrsctl", tc.args, nil, &bytes.Buffer{}, &errOut)
+			assert.Equal(t, ErrUsage, err)
+			assert.Contains(t, errOut.String(), "usage: exrsctl")
+		})
+	}
+}
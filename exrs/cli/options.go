@@ -0,0 +1,124 @@
+//Package cli administers the exercise catalog from the terminal through the gRPC API
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+//Environment variables giving the defaults of the connection flags
+const (
+	EnvEndpoint = "EXRS_ENDPOINT"
+	EnvToken    = "EXRS_TOKEN"
+)
+
+//Output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+//Options of the connection and the output, shared by every command
+type Options struct {
+	Endpoint string
+	//TLS is implied by CA and Cert
+	TLS        bool
+	CA         string
+	ServerName string
+	//Cert and Key authenticate the client to servers requiring mTLS
+	Cert string
+	Key  string
+
+	Token   string
+	Output  string
+	Timeout time.Duration
+}
+
+//RegisterFlags binds the flags of the options, defaulting to the environment
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Endpoint, "endpoint", env(EnvEndpoint, "localhost:50051"), "gRPC endpoint of the exercise service, $"+EnvEndpoint)
+	fs.BoolVar(&o.TLS, "tls", false, "connect with TLS, verifying the server with the system roots or -ca")
+	fs.StringVar(&o.CA, "ca", "", "CA file verifying the server, implies -tls")
+	fs.StringVar(&o.ServerName, "server-name", "", "name the server certificate is verified against, the endpoint host by default")
+	fs.StringVar(&o.Cert, "cert", "", "client certificate file for servers requiring mTLS, implies -tls")
+	fs.StringVar(&o.Key, "key", "", "client key file")
+	fs.StringVar(&o.Token, "token", os.Getenv(EnvToken), "bearer token authenticating the calls, $"+EnvToken)
+	fs.StringVar(&o.Output, "output", OutputTable, "output format: table, json or yaml")
+	fs.DurationVar(&o.Timeout, "timeout", 30*time.Second, "timeout of the command")
+}
+
+func env(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+//Dial connects to the endpoint, sending the token with every call
+func Dial(ctx context.Context, o Options) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithBlock()}
+	secure := o.TLS || o.CA != "" || o.Cert != ""
+	if secure {
+		config := &tls.Config{ServerName: o.ServerName}
+		if config.ServerName == "" {
+			if host, _, err := net.SplitHostPort(o.Endpoint); err == nil {
+				config.ServerName = host
+			}
+		}
+		if o.CA != "" {
+			pem, err := ioutil.ReadFile(o.CA)
+			if err != nil {
+				return nil, fmt.Errorf("could not read CA %v. Error was %v", o.CA, err)
+			}
+			config.RootCAs = x509.NewCertPool()
+			if !config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in CA %v", o.CA)
+			}
+		}
+		if o.Cert != "" {
+			cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+			if err != nil {
+				return nil, fmt.Errorf("could not load certificate %v. Error was %v", o.Cert, err)
+			}
+			config.Certificates = []tls.Certificate{cert}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if o.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{o.Token, secure}))
+	}
+	conn, err := grpc.DialContext(ctx, o.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %v. Error was %v", o.Endpoint, err)
+	}
+	return conn, nil
+}
+
+//tokenCredentials sends "authorization: Bearer <token>" with every call
+type tokenCredentials struct {
+	token  string
+	secure bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+//RequireTransportSecurity lets tokens travel in plain text only when TLS was not asked for,
+//ex: on a local port forward
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
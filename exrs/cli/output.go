@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+//printer writes the results of the commands in the chosen format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML:
+		return &printer{w, format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, use table, json or yaml", format)
+}
+
+//exercises prints m, holding the exercises l, as a table or in its canonical json form
+func (p *printer) exercises(m proto.Message, l ...*pbexrs.Exercise) error {
+	if p.format != OutputTable {
+		return p.message(m)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tKIND\tDIFFICULTY\tCATEGORIES\tMUSCLE GROUPS\tUPDATED")
+	for _, e := range l {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.Id, e.Name, enumName(e.Kind.String()), enumName(e.Difficulty.String()),
+			strings.Join(e.Categories, ","), strings.Join(e.MuscleGroups, ","), updated(e))
+	}
+	return tw.Flush()
+}
+
+//recommendations prints the recommended exercises with their score and why they were picked
+func (p *printer) recommendations(l []*pbexrs.Recommendation) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSCORE\tREASONS")
+	for _, r := range l {
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%s\n", r.Exercise.GetId(), r.Exercise.GetName(), r.Score, strings.Join(r.Reasons, "; "))
+	}
+	return tw.Flush()
+}
+
+//message prints m in its canonical json form, or that json as yaml. Tables print a summary line
+func (p *printer) message(m proto.Message) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	//protojson varies its spacing on purpose, indenting it again keeps the output stable
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, b, "", "  "); err != nil {
+		return err
+	}
+	b = indented.Bytes()
+	switch p.format {
+	case OutputYAML:
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(p.w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		_, err = fmt.Fprintln(p.w, string(b))
+		return err
+	}
+}
+
+//enumName hides the unspecified values of the enums in tables
+func enumName(s string) string {
+	if strings.HasSuffix(s, "_UNSPECIFIED") {
+		return ""
+	}
+	return s
+}
+
+func updated(e *pbexrs.Exercise) string {
+	t, err := ptypes.Timestamp(e.UpdateTime)
+	if err != nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,125 @@
+package exrs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/duplicates"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//maxCandidates caps the exercises scanned by the requests comparing the catalog, like finding its
+//duplicates. Larger catalogs are compared by their first exercises only
+const maxCandidates = 10000
+
+//FindDuplicateExercises groups the exercises of the catalog that are likely the same one, a page
+//at a time when page_size is set
+func (s *API) FindDuplicateExercises(ctx context.Context, req *pbexrs.FindDuplicateExercisesRequest) (*pbexrs.FindDuplicateExercisesResponse, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	offset, err := pageOffset(req.GetPageToken())
+	if err != nil {
+		return &pbexrs.FindDuplicateExercisesResponse{}, err
+	}
+	minScore := duplicates.DefaultMinScore
+	if req.GetMinScore() > 0 {
+		minScore = float64(req.GetMinScore()) / 100
+	}
+	//one more than the cap tells whether the catalog is larger
+	l, err := s.ExerciseStorage.List(ctx, storage.Filter{Limit: maxCandidates + 1})
+	if err != nil {
+		log.Warnf("failed to list exercises to find duplicates. Error was %v", err)
+		return &pbexrs.FindDuplicateExercisesResponse{}, err
+	}
+	if len(l) > maxCandidates {
+		log.Warnf("finding duplicates among the first %d exercises only", maxCandidates)
+		l = l[:maxCandidates]
+	}
+	found := duplicates.Find(l, minScore)
+	if offset > len(found) {
+		offset = len(found)
+	}
+	found = found[offset:]
+	var next string
+	if size := int(req.GetPageSize()); size > 0 && len(found) > size {
+		found, next = found[:size], pageToken(offset+size)
+	}
+	groups := []*pbexrs.DuplicateGroup{}
+	for _, g := range found {
+		exercises := UnmarshallExerciseList(g.Exercises)
+		for _, e := range exercises {
+			s.withAssets(ctx, e)
+		}
+		groups = append(groups, &pbexrs.DuplicateGroup{
+			Exercises: exercises,
+			Score:     g.Score,
+			Reasons:   g.Reasons,
+		})
+	}
+	log.Debugf("found %d groups of duplicates in %d exercises", len(groups), len(l))
+	return &pbexrs.FindDuplicateExercisesResponse{Groups: groups, NextPageToken: next}, nil
+}
+
+//MergeExercises adds the media and classification of the duplicates to the survivor, points their
+//references at it and deletes them, recording the merge in the revisions of all of them
+func (s *API) MergeExercises(ctx context.Context, req *pbexrs.MergeExercisesRequest) (*pbexrs.Exercise, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	log.Debugf("merging exercises %v into %v", req.GetDuplicateIds(), req.GetSurvivorId())
+	var r *storage.Exercise
+	err := s.tx.InTransaction(ctx, func(ctx context.Context) error {
+		survivor, err := s.ExerciseStorage.Read(ctx, req.GetSurvivorId())
+		if err != nil {
+			return err
+		}
+		var l []*storage.Exercise
+		for _, id := range req.GetDuplicateIds() {
+			d, err := s.ExerciseStorage.Read(ctx, id)
+			if err != nil {
+				return err
+			}
+			l = append(l, d)
+		}
+		update := duplicates.Merge(survivor, l...)
+		update.UpdateTime, update.UpdatedBy = now(), auth.Subject(ctx)
+		if r, err = s.ExerciseStorage.Update(ctx, survivor.Id, update); err != nil {
+			return err
+		}
+		for _, d := range l {
+			if err := s.references.Rewrite(ctx, d.Id, survivor.Id); err != nil {
+				return err
+			}
+			if _, err := s.ExerciseStorage.Delete(ctx, d.Id); err != nil {
+				return err
+			}
+			if err := s.record(ctx, storage.RevisionMerge, d.Id, nil, survivor.Id); err != nil {
+				return err
+			}
+		}
+		return s.record(ctx, storage.RevisionMerge, survivor.Id, r, req.GetDuplicateIds()...)
+	})
+	if err != nil {
+		log.Warnf("could not merge exercises %v into %v. Error was %v", req.GetDuplicateIds(), req.GetSurvivorId(), err)
+		return &pbexrs.Exercise{}, err
+	}
+	log.Debugf("merged exercises %v into %v", req.GetDuplicateIds(), req.GetSurvivorId())
+	return s.withAssets(ctx, UnmarshallExercise(r)), nil
+}
+
+//mergeRule rejects merges of an exercise into itself or of the same duplicate twice
+func mergeRule(_ string, _ protoreflect.FieldDescriptor, v protoreflect.Value, _ bool, vs *validation.Violations) {
+	req, ok := v.Message().Interface().(*pbexrs.MergeExercisesRequest)
+	if !ok {
+		return
+	}
+	seen := map[string]bool{req.SurvivorId: true}
+	for i, id := range req.DuplicateIds {
+		if seen[id] {
+			vs.Add(fmt.Sprintf("duplicate_ids[%d]", i), "must differ from the survivor and the other duplicates")
+		}
+		seen[id] = true
+	}
+}
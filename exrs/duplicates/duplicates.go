@@ -0,0 +1,316 @@
+//Package duplicates finds the exercises of the catalog that are likely the same one, like "Push Up",
+//"push-up" and "Pushups", and merges them
+package duplicates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+)
+
+//DefaultMinScore is the score two exercises need to be grouped when none is given
+const DefaultMinScore = 0.8
+
+//prefixLength is the number of runes of the normalized names that put exercises in the same bucket
+const prefixLength = 3
+
+//window is the number of exercises of a bucket, sorted by normalized name, each exercise is compared
+//with. Buckets of the most common muscle groups hold a big part of the catalog, comparing every pair
+//of them grows with its square
+const window = 50
+
+//nameWeight is the part of the score given by the names when both exercises have muscle groups,
+//the rest is their overlap
+const nameWeight = 0.8
+
+//Group of exercises likely to be the same
+type Group struct {
+	//Exercises sorted by id
+	Exercises []*storage.Exercise
+	//Score is the lowest score of the pairs linking the exercises, from 0 to 1
+	Score float64
+	//Reasons why the pairs were linked
+	Reasons []string
+}
+
+//Normalize reduces a name to its letters and digits, lower case, dropping the plural of its words,
+//so "Push Up", "push-up", "Push Ups" and "Pushups" are all "pushup"
+func Normalize(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = singular(w)
+	}
+	//short words like "ups" are only plural at the end of the name
+	return singular(strings.Join(words, ""))
+}
+
+//singular drops the s ending a word of more than 3 letters, unless it ends in ss
+func singular(w string) string {
+	if len(w) <= 3 || !strings.HasSuffix(w, "s") || strings.HasSuffix(w, "ss") || !unicode.IsLetter(rune(w[len(w)-2])) {
+		return w
+	}
+	return w[:len(w)-1]
+}
+
+//Similarity of two normalized names from 0 to 1, one minus their edit distance over the length of
+//the longest
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(distance(ra, rb))/float64(longest)
+}
+
+//distance is the levenshtein distance of a and b, keeping a single row of the matrix
+func distance(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			next := min(row[j]+1, row[j-1]+1, diagonal+cost)
+			diagonal, row[j] = row[j], next
+		}
+	}
+	return row[len(b)]
+}
+
+func min(n int, l ...int) int {
+	for _, m := range l {
+		if m < n {
+			n = m
+		}
+	}
+	return n
+}
+
+//Score tells how likely a and b are the same exercise, from 0 to 1, and why. The muscle groups
+//weigh in only when both exercises have some
+func Score(a, b *storage.Exercise) (float64, []string) {
+	na, nb := Normalize(a.Name), Normalize(b.Name)
+	score := Similarity(na, nb)
+	var reasons []string
+	if na == nb {
+		reasons = append(reasons, fmt.Sprintf("%q and %q have the same normalized name %q", a.Name, b.Name, na))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("%q and %q names are %.0f%% similar", a.Name, b.Name, score*100))
+	}
+	if len(a.MuscleGroups) == 0 || len(b.MuscleGroups) == 0 {
+		return score, reasons
+	}
+	shared, all := overlap(a.MuscleGroups, b.MuscleGroups)
+	score = nameWeight*score + (1-nameWeight)*float64(shared)/float64(all)
+	if shared > 0 {
+		reasons = append(reasons, fmt.Sprintf("%q and %q share %d of %d muscle groups", a.Name, b.Name, shared, all))
+	}
+	return score, reasons
+}
+
+//overlap counts the groups in both a and b, and in either of them, in any case
+func overlap(a, b []string) (int, int) {
+	set := make(map[string]int)
+	for _, g := range a {
+		set[strings.ToLower(g)] |= 1
+	}
+	for _, g := range b {
+		set[strings.ToLower(g)] |= 2
+	}
+	var shared int
+	for _, in := range set {
+		if in == 3 {
+			shared++
+		}
+	}
+	return shared, len(set)
+}
+
+//Find groups the exercises scoring at least minScore with one another, directly or through other
+//exercises of the group. Only the candidates sharing the first letters of their normalized names
+//or a muscle group are scored, each with the nearest names of its bucket.
+//Groups are sorted by score, highest first
+func Find(l []*storage.Exercise, minScore float64) []Group {
+	parent := make([]int, len(l))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	type link struct {
+		score   float64
+		reasons []string
+	}
+	links := make(map[int][]link)
+	normalized := make([]string, len(l))
+	for i, e := range l {
+		normalized[i] = Normalize(e.Name)
+	}
+	scored := make(map[[2]int]bool)
+	for _, bucket := range buckets(l, normalized) {
+		for a, first := range bucket {
+			for _, second := range bucket[a+1 : min(len(bucket), a+1+window)] {
+				i, j := first, second
+				if i > j {
+					i, j = j, i
+				}
+				//an exercise listing a muscle group twice is twice in its bucket
+				if i == j || scored[[2]int{i, j}] {
+					continue
+				}
+				scored[[2]int{i, j}] = true
+				//names too far apart can't reach the score whatever their muscle groups
+				if 1-Similarity(normalized[i], normalized[j]) > (1-minScore)/nameWeight {
+					continue
+				}
+				score, reasons := Score(l[i], l[j])
+				if score < minScore {
+					continue
+				}
+				parent[root(j)] = root(i)
+				links[i] = append(links[i], link{score, reasons})
+			}
+		}
+	}
+	byRoot := make(map[int]*Group)
+	var groups []*Group
+	for i, e := range l {
+		r := root(i)
+		g, ok := byRoot[r]
+		if !ok {
+			g = &Group{Score: 1}
+			byRoot[r] = g
+			groups = append(groups, g)
+		}
+		g.Exercises = append(g.Exercises, e)
+		for _, k := range links[i] {
+			if k.score < g.Score {
+				g.Score = k.score
+			}
+			g.Reasons = append(g.Reasons, k.reasons...)
+		}
+	}
+	var found []Group
+	for _, g := range groups {
+		if len(g.Exercises) < 2 {
+			continue
+		}
+		sort.Slice(g.Exercises, func(i, j int) bool { return g.Exercises[i].Id < g.Exercises[j].Id })
+		found = append(found, *g)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Score != found[j].Score {
+			return found[i].Score > found[j].Score
+		}
+		return found[i].Exercises[0].Id < found[j].Exercises[0].Id
+	})
+	return found
+}
+
+//buckets returns the indexes of the exercises by the prefix of their normalized name and by each of
+//their muscle groups, sorted by normalized name so the names closest to one another are next
+func buckets(l []*storage.Exercise, normalized []string) [][]int {
+	byKey := make(map[string][]int)
+	var keys []string
+	add := func(key string, i int) {
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], i)
+	}
+	for i, e := range l {
+		prefix := []rune(normalized[i])
+		if len(prefix) > prefixLength {
+			prefix = prefix[:prefixLength]
+		}
+		add("name:"+string(prefix), i)
+		for _, g := range e.MuscleGroups {
+			add("muscle group:"+strings.ToLower(g), i)
+		}
+	}
+	var found [][]int
+	for _, k := range keys {
+		bucket := byKey[k]
+		if len(bucket) < 2 {
+			continue
+		}
+		sort.SliceStable(bucket, func(a, b int) bool { return normalized[bucket[a]] < normalized[bucket[b]] })
+		found = append(found, bucket)
+	}
+	return found
+}
+
+//Merge returns the update of survivor adding the media, categories, muscles and muscle groups of
+//the duplicates it is missing. Its other fields are kept
+func Merge(survivor *storage.Exercise, duplicates ...*storage.Exercise) *storage.Exercise {
+	update := &storage.Exercise{
+		Categories:   survivor.Categories,
+		Muscles:      survivor.Muscles,
+		MuscleGroups: survivor.MuscleGroups,
+		Images:       survivor.Images,
+		ImageIDs:     survivor.ImageIDs,
+		Videos:       append([]storage.Video(nil), survivor.Videos...),
+	}
+	for _, d := range duplicates {
+		update.Categories = union(update.Categories, d.Categories, strings.ToLower)
+		update.Muscles = union(update.Muscles, d.Muscles, strings.ToLower)
+		update.MuscleGroups = union(update.MuscleGroups, d.MuscleGroups, strings.ToLower)
+		update.Images = union(update.Images, d.Images, nil)
+		update.ImageIDs = union(update.ImageIDs, d.ImageIDs, nil)
+		for _, v := range d.Videos {
+			if !hasVideo(update.Videos, v) {
+				update.Videos = append(update.Videos, v)
+			}
+		}
+	}
+	return update
+}
+
+//union returns a followed by the values of b it misses, compared by their key when key is not nil
+func union(a, b []string, key func(string) string) []string {
+	if key == nil {
+		key = func(s string) string { return s }
+	}
+	l := append([]string(nil), a...)
+	seen := make(map[string]bool, len(a))
+	for _, s := range a {
+		seen[key(s)] = true
+	}
+	for _, s := range b {
+		if !seen[key(s)] {
+			seen[key(s)] = true
+			l = append(l, s)
+		}
+	}
+	return l
+}
+
+//hasVideo tells whether l holds v, videos are the same when they show the same url or asset
+func hasVideo(l []storage.Video, v storage.Video) bool {
+	for _, o := range l {
+		if o.Provider == v.Provider && o.URL == v.URL && o.AssetID == v.AssetID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+// +build unit
+
+package duplicates
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"Push Up", "pushup"},
+		{"push-up", "pushup"},
+		{"Pushups", "pushup"},
+		{"  PUSH_UPS ", "pushup"},
+		{"Push Ups", "pushup"},
+		{"Jumping Jacks", "jumpingjack"},
+		{"Bench Press", "benchpress"},
+		{"Abs", "abs"},
+		{"Curl 21s", "curl21s"},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, Normalize(tc.name))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("pushup", "pushup"))
+	assert.Equal(t, 1.0, Similarity("", ""))
+	assert.Equal(t, 0.0, Similarity("abc", ""))
+	assert.InDelta(t, 1-2.0/7, Similarity("sittups", "situp"), 0.001)
+	assert.InDelta(t, 1-1.0/6, Similarity("squat", "squats"), 0.001)
+}
+
+func TestScore(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a, b    *storage.Exercise
+		min     float64
+		max     float64
+		reasons int
+	}{
+		{"same name", &storage.Exercise{Name: "Push Up"}, &storage.Exercise{Name: "Pushups"}, 1, 1, 1},
+		{"same name and groups", &storage.Exercise{Name: "Push Up", MuscleGroups: []string{"Chest"}}, &storage.Exercise{Name: "push-up", MuscleGroups: []string{"chest"}}, 1, 1, 2},
+		{"same name other groups", &storage.Exercise{Name: "Push Up", MuscleGroups: []string{"chest"}}, &storage.Exercise{Name: "push-up", MuscleGroups: []string{"legs"}}, 0.8, 0.8, 1},
+		{"typo", &storage.Exercise{Name: "Deadlift"}, &storage.Exercise{Name: "Deadlfit"}, 0.74, 0.76, 1},
+		{"different", &storage.Exercise{Name: "Squat", MuscleGroups: []string{"legs"}}, &storage.Exercise{Name: "Bench Press", MuscleGroups: []string{"chest"}}, 0, 0.2, 1},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			score, reasons := Score(tc.a, tc.b)
+			assert.GreaterOrEqual(t, score, tc.min-1e-9)
+			assert.LessOrEqual(t, score, tc.max+1e-9)
+			assert.Len(t, reasons, tc.reasons)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	l := []*storage.Exercise{
+		{Id: "1", Name: "Push Up", MuscleGroups: []string{"chest"}},
+		{Id: "2", Name: "Squat", MuscleGroups: []string{"legs"}},
+		{Id: "3", Name: "push-up", MuscleGroups: []string{"chest", "triceps"}},
+		{Id: "4", Name: "Pushups"},
+		{Id: "5", Name: "Squats", MuscleGroups: []string{"legs"}},
+		{Id: "6", Name: "Bench Press"},
+	}
+	groups := Find(l, DefaultMinScore)
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, []*storage.Exercise{l[1], l[4]}, groups[0].Exercises, "same names and muscle groups first")
+		assert.Equal(t, 1.0, groups[0].Score)
+		assert.Equal(t, []*storage.Exercise{l[0], l[2], l[3]}, groups[1].Exercises)
+		assert.InDelta(t, 0.9, groups[1].Score, 0.001, "lowest score of the pairs")
+		assert.Contains(t, groups[1].Reasons, `"Push Up" and "Pushups" have the same normalized name "pushup"`)
+		assert.Contains(t, groups[1].Reasons, `"Push Up" and "push-up" share 1 of 2 muscle groups`)
+	}
+	groups = Find(l, 1)
+	if assert.Len(t, groups, 2) {
+		assert.Len(t, groups[0].Exercises, 3, "push ups not sharing every muscle group are linked through Pushups")
+		assert.Equal(t, 1.0, groups[0].Score)
+	}
+	assert.Empty(t, Find(l[:2], DefaultMinScore))
+	assert.Empty(t, Find(nil, DefaultMinScore))
+}
+
+func TestFindCandidates(t *testing.T) {
+	testCases := []struct {
+		name    string
+		l       []*storage.Exercise
+		grouped bool
+	}{
+		{"same name prefix", []*storage.Exercise{{Id: "1", Name: "Deadlift"}, {Id: "2", Name: "Deadlfit"}}, true},
+		{"different name prefixes", []*storage.Exercise{{Id: "1", Name: "Push Up"}, {Id: "2", Name: "XPush Up"}}, false},
+		{"shared muscle group", []*storage.Exercise{{Id: "1", Name: "Push Up", MuscleGroups: []string{"chest"}}, {Id: "2", Name: "XPush Up", MuscleGroups: []string{"Chest"}}}, true},
+		{"repeated muscle group", []*storage.Exercise{{Id: "1", Name: "Push Up", MuscleGroups: []string{"chest", "Chest"}}}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.grouped, len(Find(tc.l, 0.75)) == 1)
+		})
+	}
+}
+
+func TestFindLargeBucket(t *testing.T) {
+	//random names are too far apart to be grouped
+	r := rand.New(rand.NewSource(1))
+	var l []*storage.Exercise
+	for i := 0; i < 10*window; i++ {
+		name := make([]byte, 12)
+		for j := range name {
+			name[j] = byte('a' + r.Intn(26))
+		}
+		l = append(l, &storage.Exercise{Id: fmt.Sprintf("%04d", i), Name: string(name), MuscleGroups: []string{"chest"}})
+	}
+	l = append(l,
+		&storage.Exercise{Id: "a", Name: "Push Up", MuscleGroups: []string{"chest"}},
+		&storage.Exercise{Id: "b", Name: "Pushups", MuscleGroups: []string{"chest"}})
+	groups := Find(l, DefaultMinScore)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, []*storage.Exercise{l[len(l)-2], l[len(l)-1]}, groups[0].Exercises, "closest names of a bucket are compared")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	survivor := &storage.Exercise{
+		Id:           "1",
+		Name:         "Push Up",
+		Description:  "kept",
+		Categories:   []string{"Strength"},
+		MuscleGroups: []string{"chest"},
+		Images:       []string{"https://example.com/a.jpg"},
+		Videos:       []storage.Video{{Provider: "youtube", URL: "https://youtu.be/a"}},
+	}
+	update := Merge(survivor,
+		&storage.Exercise{Name: "push-up", Description: "dropped", Categories: []string{"strength", "bodyweight"}, MuscleGroups: []string{"triceps"},
+			Images: []string{"https://example.com/a.jpg", "https://example.com/b.jpg"}, ImageIDs: []string{"i1"},
+			Videos: []storage.Video{{Provider: "youtube", URL: "https://youtu.be/a"}, {Provider: "hosted", AssetID: "v1"}}},
+		&storage.Exercise{Name: "Pushups", Muscles: []string{"pectoralis major"}, MuscleGroups: []string{"Chest", "shoulders"}},
+	)
+	assert.Equal(t, &storage.Exercise{
+		Categories:   []string{"Strength", "bodyweight"},
+		Muscles:      []string{"pectoralis major"},
+		MuscleGroups: []string{"chest", "triceps", "shoulders"},
+		Images:       []string{"https://example.com/a.jpg", "https://example.com/b.jpg"},
+		ImageIDs:     []string{"i1"},
+		Videos:       []storage.Video{{Provider: "youtube", URL: "https://youtu.be/a"}, {Provider: "hosted", AssetID: "v1"}},
+	}, update)
+	assert.Equal(t, []string{"Strength"}, survivor.Categories, "the survivor is not changed")
+	assert.Len(t, survivor.Videos, 1)
+}
@@ -0,0 +1,159 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/assets"
+	"github.com/maxvw8/exercise_lib/exrs/references"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFindDuplicateExercises(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	for _, name := range []string{"Push Up", "push-up", "Pushups", "Squat", "Deadlift", "Deadlfit"} {
+		_, err := repo.Create(ctx, &storage.Exercise{Name: name})
+		require.NoError(t, err)
+	}
+	api, _ := Server(repo, repo)
+
+	resp, err := api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Groups, 1, "deadlift typos score 75%")
+	var names []string
+	for _, e := range resp.Groups[0].Exercises {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"Push Up", "push-up", "Pushups"}, names)
+	assert.Equal(t, 1.0, resp.Groups[0].Score)
+	assert.NotEmpty(t, resp.Groups[0].Reasons)
+
+	resp, err = api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{MinScore: 70})
+	require.NoError(t, err)
+	assert.Len(t, resp.Groups, 2)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestFindDuplicateExercisesPages(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	for _, name := range []string{"Push Up", "push-up", "Deadlift", "Deadlfit"} {
+		_, err := repo.Create(ctx, &storage.Exercise{Name: name})
+		require.NoError(t, err)
+	}
+	api, _ := Server(repo, repo)
+
+	first, err := api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{MinScore: 70, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, first.Groups, 1)
+	require.NotEmpty(t, first.NextPageToken)
+	second, err := api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{MinScore: 70, PageSize: 1, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Groups, 1)
+	assert.Empty(t, second.NextPageToken)
+	assert.NotEqual(t, first.Groups[0].Exercises[0].Id, second.Groups[0].Exercises[0].Id)
+
+	_, err = api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{PageToken: "nope"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+//resolver resolves every asset id into an image
+type resolver struct{}
+
+func (resolver) Resolve(_ context.Context, id string) (*assets.Asset, error) {
+	return &assets.Asset{ID: id, ContentType: "image/png", URL: "https://api.example.com/v1/assets/" + id}, nil
+}
+
+func TestFindDuplicateExercisesAssets(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	for _, name := range []string{"Push Up", "push-up"} {
+		_, err := repo.Create(ctx, &storage.Exercise{Name: name, ImageIDs: []string{"5f1d7f3b2c8e4a0001a1b2c3"}})
+		require.NoError(t, err)
+	}
+	api, _ := Server(repo, repo, WithAssets(resolver{}))
+
+	resp, err := api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Groups, 1)
+	for _, e := range resp.Groups[0].Exercises {
+		if assert.Len(t, e.ImageAssets, 1) {
+			assert.Equal(t, "https://api.example.com/v1/assets/5f1d7f3b2c8e4a0001a1b2c3", e.ImageAssets[0].Url)
+		}
+	}
+}
+
+func TestMergeExercises(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	survivor, err := repo.Create(ctx, &storage.Exercise{Name: "Push Up", Description: "kept", MuscleGroups: []string{"chest"}})
+	require.NoError(t, err)
+	first, err := repo.Create(ctx, &storage.Exercise{Name: "push-up", MuscleGroups: []string{"triceps"}, Images: []string{"https://example.com/push-up.jpg"}})
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, &storage.Exercise{Name: "Pushups", Categories: []string{"bodyweight"}})
+	require.NoError(t, err)
+	w := &workouts{exerciseID: first.Id, ids: []string{"chest-day"}}
+	registry := references.NewRegistry()
+	registry.Register(w)
+	api, _ := Server(repo, repo, WithRevisions(repo), WithTransactions(repo), WithReferences(registry))
+
+	merged, err := api.MergeExercises(ctx, &pbexrs.MergeExercisesRequest{SurvivorId: survivor.Id, DuplicateIds: []string{first.Id, second.Id}})
+	require.NoError(t, err)
+	assert.Equal(t, survivor.Id, merged.Id)
+	assert.Equal(t, "Push Up", merged.Name)
+	assert.Equal(t, "kept", merged.Description)
+	assert.Equal(t, []string{"chest", "triceps"}, merged.MuscleGroups)
+	assert.Equal(t, []string{"bodyweight"}, merged.Categories)
+	assert.Equal(t, []string{"https://example.com/push-up.jpg"}, merged.Images)
+	assert.Equal(t, survivor.Id, w.exerciseID, "references to the duplicates point at the survivor")
+
+	l, err := repo.List(ctx, storage.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, l, 1, "duplicates are deleted")
+
+	revisions, err := api.ListExerciseRevisions(ctx, &pbexrs.ListExerciseRevisionsRequest{Id: survivor.Id})
+	require.NoError(t, err)
+	require.Len(t, revisions.Revisions, 1)
+	assert.Equal(t, pbexrs.RevisionAction_MERGE, revisions.Revisions[0].Action)
+	assert.Equal(t, []string{first.Id, second.Id}, revisions.Revisions[0].MergedWith)
+	assert.Equal(t, "kept", revisions.Revisions[0].Exercise.Description)
+	revisions, err = api.ListExerciseRevisions(ctx, &pbexrs.ListExerciseRevisionsRequest{Id: second.Id})
+	require.NoError(t, err)
+	require.Len(t, revisions.Revisions, 1)
+	assert.Equal(t, pbexrs.RevisionAction_MERGE, revisions.Revisions[0].Action)
+	assert.Equal(t, []string{survivor.Id}, revisions.Revisions[0].MergedWith)
+	assert.Nil(t, revisions.Revisions[0].Exercise)
+}
+
+func TestMergeExercisesRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	survivor, err := repo.Create(ctx, &storage.Exercise{Name: "Push Up"})
+	require.NoError(t, err)
+	duplicate, err := repo.Create(ctx, &storage.Exercise{Name: "push-up", MuscleGroups: []string{"chest"}})
+	require.NoError(t, err)
+	registry := references.NewRegistry()
+	registry.Register(&workouts{exerciseID: duplicate.Id, err: errors.New("workouts are down")})
+	api, _ := Server(repo, repo, WithRevisions(repo), WithTransactions(repo), WithReferences(registry))
+
+	_, err = api.MergeExercises(ctx, &pbexrs.MergeExercisesRequest{SurvivorId: survivor.Id, DuplicateIds: []string{duplicate.Id}})
+	assert.Error(t, err)
+	_, err = api.MergeExercises(ctx, &pbexrs.MergeExercisesRequest{SurvivorId: survivor.Id, DuplicateIds: []string{"5f1d7f3b2c8e4a0001a1b2c3"}})
+	assert.Error(t, err, "duplicates must exist")
+
+	l, err := repo.List(ctx, storage.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, l, 2, "nothing is deleted when references can not be rewritten")
+	kept, err := repo.Read(ctx, survivor.Id)
+	require.NoError(t, err)
+	assert.Empty(t, kept.MuscleGroups, "nor is the survivor updated")
+}
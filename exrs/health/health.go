@@ -0,0 +1,123 @@
+//Package health reports whether the servers can take traffic: the standard grpc.health.v1 service
+//on the gRPC side, /healthz and /readyz on the HTTP side
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+//Service is the name of the exercise service in health checks, "" stands for the whole server
+const Service = "pbexrs.ExerciseService"
+
+const (
+	//Interval between two checks of the storage
+	Interval = 5 * time.Second
+	//Timeout of a single check
+	Timeout = 2 * time.Second
+)
+
+//Check returns an error when a dependency is unavailable
+type Check func(context.Context) error
+
+//Pinger is satisfied by storage.ExerciseStorage
+type Pinger interface {
+	Ping(context.Context) error
+}
+
+//Checker keeps the grpc health status of the server in line with the storage answering pings
+type Checker struct {
+	*health.Server
+	check Check
+}
+
+//NewChecker starts NOT_SERVING until the first successful ping
+func NewChecker(p Pinger) *Checker {
+	c := &Checker{health.NewServer(), p.Ping}
+	c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+func (c *Checker) set(s healthpb.HealthCheckResponse_ServingStatus) {
+	c.SetServingStatus("", s)
+	c.SetServingStatus(Service, s)
+}
+
+//Update pings the storage once and updates the status
+func (c *Checker) Update(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	err := c.check(ctx)
+	if err != nil {
+		c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+		return err
+	}
+	c.set(healthpb.HealthCheckResponse_SERVING)
+	return nil
+}
+
+//Run updates the status every interval until ctx is done, then reports NOT_SERVING for good
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		c.Update(ctx)
+		select {
+		case <-ctx.Done():
+			c.Shutdown()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+//Remote checks a gRPC server through its health service, the gateway is ready when the server is
+func Remote(client healthpb.HealthClient) Check {
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: Service})
+		if err != nil {
+			return fmt.Errorf("health check failed. Error was %v", err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("server is %v", resp.Status)
+		}
+		return nil
+	}
+}
+
+//Handler serves /healthz, answering as long as the process runs, and /readyz, answering
+//503 when ready fails
+func Handler(ready Check) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, nil)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), Timeout)
+		defer cancel()
+		err := ready(ctx)
+		if err != nil {
+			reply(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		reply(w, http.StatusOK, nil)
+	})
+	return mux
+}
+
+func reply(w http.ResponseWriter, code int, err error) {
+	body := map[string]string{"status": "ok"}
+	if err != nil {
+		body = map[string]string{"status": "unavailable", "error": err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
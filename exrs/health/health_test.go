@@ -0,0 +1,63 @@
+// +build unit
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (f *fakePinger) Ping(context.Context) error {
+	return f.err
+}
+
+func status(t *testing.T, c *Checker) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := c.Check(context.Background(), &healthpb.HealthCheckRequest{Service: Service})
+	assert.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	p := &fakePinger{}
+	c := NewChecker(p)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c))
+	assert.NoError(t, c.Update(context.Background()))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, c))
+	p.err = errors.New("no reachable servers")
+	assert.Error(t, c.Update(context.Background()))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c))
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+		err  error
+		code int
+	}{
+		{"live", "/healthz", nil, http.StatusOK},
+		{"live while storage is down", "/healthz", errors.New("down"), http.StatusOK},
+		{"ready", "/readyz", nil, http.StatusOK},
+		{"not ready", "/readyz", errors.New("down"), http.StatusServiceUnavailable},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			Handler((&fakePinger{tc.err}).Ping).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			assert.Equal(t, tc.code, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+//Package httpcache gives the gateway responses validators and answers conditional requests, so
+//HTTP caches can keep the catalog
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	protov2 "google.golang.org/protobuf/proto"
+)
+
+//ForwardResponseOption sets the ETag header of the exercises the gateway returns, a digest of the
+//whole response so it tells apart the variants of a request, and the Last-Modified header of a
+//single exercise. Lists get no Last-Modified, the newest update among them doesn't change when
+//exercises are deleted. It is a runtime.WithForwardResponseOption
+func ForwardResponseOption(_ context.Context, w http.ResponseWriter, m proto.Message) error {
+	switch e := m.(type) {
+	case *pbexrs.Exercise:
+		if modified := timeOf(e.GetUpdateTime()); !modified.IsZero() {
+			w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+		}
+	case *pbexrs.ListExercisesResponse:
+	default:
+		return nil
+	}
+	b, err := protov2.MarshalOptions{Deterministic: true}.Marshal(proto.MessageV2(m))
+	if err != nil {
+		return fmt.Errorf("could not digest the response. Error was %v", err)
+	}
+	sum := sha256.Sum256(b)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	return nil
+}
+
+func timeOf(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+//Handler sets Cache-Control on the successful GET responses of h, and answers 304 Not Modified
+//to the If-None-Match and If-Modified-Since requests their validators match. Other requests
+//go through untouched
+func Handler(h http.Handler, cacheControl string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			h.ServeHTTP(w, r)
+			return
+		}
+		b := &bufferedWriter{header: http.Header{}, code: http.StatusOK}
+		h.ServeHTTP(b, r)
+		for k, v := range b.header {
+			w.Header()[k] = v
+		}
+		if b.code != http.StatusOK {
+			w.WriteHeader(b.code)
+			w.Write(b.body.Bytes())
+			return
+		}
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		if notModified(r, w.Header()) {
+			for _, k := range []string{"Content-Type", "Content-Length"} {
+				w.Header().Del(k)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(b.code)
+		w.Write(b.body.Bytes())
+	})
+}
+
+//notModified tells whether the validators of the response match the conditions of r. If-None-Match
+//takes precedence over If-Modified-Since as in RFC 7232
+func notModified(r *http.Request, h http.Header) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := h.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(h.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+//bufferedWriter holds the response until its validators are checked
+type bufferedWriter struct {
+	header http.Header
+	code   int
+	wrote  bool
+	body   bytes.Buffer
+}
+
+func (b *bufferedWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedWriter) WriteHeader(code int) {
+	if !b.wrote {
+		b.code, b.wrote = code, true
+	}
+}
+
+func (b *bufferedWriter) Write(p []byte) (int, error) {
+	b.wrote = true
+	return b.body.Write(p)
+}
@@ -0,0 +1,109 @@
+// +build unit
+
+package httpcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	updated := time.Date(2020, 6, 1, 10, 0, 0, 500, time.UTC)
+	ts, _ := ptypes.TimestampProto(updated)
+	squat := &pbexrs.Exercise{Id: "1", Name: "squat", UpdateTime: ts}
+	lastModified := "Mon, 01 Jun 2020 10:00:00 GMT"
+	etagOf := func(m proto.Message) string {
+		w := httptest.NewRecorder()
+		ForwardResponseOption(context.Background(), w, m)
+		return w.Header().Get("ETag")
+	}
+	etag := etagOf(squat)
+	testCases := []struct {
+		name         string
+		method       string
+		message      proto.Message
+		header       map[string]string
+		code         int
+		etag         string
+		lastModified string
+		cacheControl string
+	}{
+		{"validators", http.MethodGet, squat, nil, http.StatusOK, etag, lastModified, "no-cache"},
+		{"matching etag", http.MethodGet, squat, map[string]string{"If-None-Match": etag}, http.StatusNotModified, etag, lastModified, "no-cache"},
+		{"one of the etags", http.MethodGet, squat, map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified, etag, lastModified, "no-cache"},
+		{"any etag", http.MethodGet, squat, map[string]string{"If-None-Match": "*"}, http.StatusNotModified, etag, lastModified, "no-cache"},
+		{"other etag", http.MethodGet, squat, map[string]string{"If-None-Match": `"other"`}, http.StatusOK, etag, lastModified, "no-cache"},
+		{"etag over date", http.MethodGet, squat, map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified}, http.StatusOK, etag, lastModified, "no-cache"},
+		{"not modified since", http.MethodGet, squat, map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified, etag, lastModified, "no-cache"},
+		{"modified since", http.MethodGet, squat, map[string]string{"If-Modified-Since": "Sun, 31 May 2020 10:00:00 GMT"}, http.StatusOK, etag, lastModified, "no-cache"},
+		{"list", http.MethodGet, &pbexrs.ListExercisesResponse{Exercises: []*pbexrs.Exercise{squat, {Id: "2"}}}, nil, http.StatusOK,
+			etagOf(&pbexrs.ListExercisesResponse{Exercises: []*pbexrs.Exercise{squat, {Id: "2"}}}), "", "no-cache"},
+		{"list since", http.MethodGet, &pbexrs.ListExercisesResponse{Exercises: []*pbexrs.Exercise{squat}}, map[string]string{"If-Modified-Since": lastModified}, http.StatusOK,
+			etagOf(&pbexrs.ListExercisesResponse{Exercises: []*pbexrs.Exercise{squat}}), "", "no-cache"},
+		{"other messages", http.MethodGet, &pbexrs.ListTaxonomyTermsResponse{}, map[string]string{"If-None-Match": "*"}, http.StatusOK, "", "", "no-cache"},
+		{"writes", http.MethodPut, squat, map[string]string{"If-None-Match": etag}, http.StatusOK, etag, lastModified, ""},
+		{"errors", http.MethodGet, nil, nil, http.StatusNotFound, "", "", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mux := runtime.NewServeMux(runtime.WithForwardResponseOption(ForwardResponseOption))
+			h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.message == nil {
+					http.NotFound(w, r)
+					return
+				}
+				runtime.ForwardResponseMessage(r.Context(), mux, &runtime.JSONPb{}, w, r, tc.message, ForwardResponseOption)
+			}), "no-cache")
+			r := httptest.NewRequest(tc.method, "/v1/exercises/1", nil)
+			for k, v := range tc.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tc.code, w.Code)
+			assert.Equal(t, tc.etag, w.Header().Get("ETag"))
+			assert.Equal(t, tc.lastModified, w.Header().Get("Last-Modified"))
+			assert.Equal(t, tc.cacheControl, w.Header().Get("Cache-Control"))
+			if tc.code == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.NotEmpty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestForwardResponseOptionVersions(t *testing.T) {
+	etagOf := func(m proto.Message) string {
+		w := httptest.NewRecorder()
+		ForwardResponseOption(context.Background(), w, m)
+		return w.Header().Get("ETag")
+	}
+	first, _ := ptypes.TimestampProto(time.Unix(100, 0))
+	second, _ := ptypes.TimestampProto(time.Unix(200, 0))
+	assert.Equal(t, etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first}), etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first}))
+	assert.NotEqual(t, etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first}), etagOf(&pbexrs.Exercise{Id: "1", Name: "squat", UpdateTime: first}),
+		"writes within the same update time")
+	front := &pbexrs.Exercise{Id: "1", UpdateTime: first, Videos: []*pbexrs.Video{{Url: "https://example.com/front.mp4", Angle: pbexrs.CameraAngle_FRONT}}}
+	side := &pbexrs.Exercise{Id: "1", UpdateTime: first, Videos: []*pbexrs.Video{{Url: "https://example.com/side.mp4", Angle: pbexrs.CameraAngle_SIDE}}}
+	assert.NotEqual(t, etagOf(front), etagOf(side), "videos of other angles")
+	assert.NotEqual(t, etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first, Images: []string{"https://a.example.com/v1/assets/1"}}),
+		etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first, Images: []string{"https://b.example.com/v1/assets/1"}}), "assets of other public urls")
+	assert.NotEqual(t, etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first}), etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: second}))
+	assert.NotEqual(t, etagOf(&pbexrs.Exercise{Id: "1", UpdateTime: first}), etagOf(&pbexrs.Exercise{Id: "2", UpdateTime: first}))
+	page := []*pbexrs.Exercise{{Id: "1", UpdateTime: first}}
+	assert.NotEqual(t, etagOf(&pbexrs.ListExercisesResponse{Exercises: page}), etagOf(&pbexrs.ListExercisesResponse{Exercises: page, NextPageToken: "next"}))
+	deleted := append(page, &pbexrs.Exercise{Id: "2", UpdateTime: first})
+	assert.NotEqual(t, etagOf(&pbexrs.ListExercisesResponse{Exercises: page}), etagOf(&pbexrs.ListExercisesResponse{Exercises: deleted}), "deletes change the etag of lists")
+}
@@ -0,0 +1,106 @@
+package exrs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/assets"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+)
+
+//AssetResolver looks up the uploaded assets referenced by exercises
+type AssetResolver interface {
+	Resolve(context.Context, string) (*assets.Asset, error)
+}
+
+//WithAssets lets exercises reference uploaded assets, resolving them into urls on responses
+func WithAssets(r AssetResolver) Option {
+	return func(s *API) {
+		s.assets = r
+	}
+}
+
+//checkAssets checks the referenced images and hosted videos exist and are of the expected media type
+func (s *API) checkAssets(ctx context.Context, e *pbexrs.Exercise, violations *validation.Violations) error {
+	type ref struct {
+		field string
+		id    string
+		valid func(*assets.Asset) bool
+		want  string
+	}
+	var refs []ref
+	for i, id := range e.GetImageIds() {
+		refs = append(refs, ref{fmt.Sprintf("exercise.image_ids[%d]", i), id, (*assets.Asset).IsImage, "an image"})
+	}
+	for i, v := range e.GetVideos() {
+		if v.GetProvider() == pbexrs.VideoProvider_HOSTED && v.GetAssetId() != "" {
+			refs = append(refs, ref{fmt.Sprintf("exercise.videos[%d].asset_id", i), v.GetAssetId(), (*assets.Asset).IsVideo, "a video"})
+		}
+	}
+	for _, ref := range refs {
+		if s.assets == nil {
+			violations.Add(ref.field, "asset uploads are not enabled")
+			continue
+		}
+		a, err := s.assets.Resolve(ctx, ref.id)
+		if errors.Is(err, assets.ErrNotFound) {
+			violations.Add(ref.field, fmt.Sprintf("unknown asset %q", ref.id))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !ref.valid(a) {
+			violations.Add(ref.field, fmt.Sprintf("asset %q is not %s", ref.id, ref.want))
+		}
+	}
+	return nil
+}
+
+//withAssets fills the output only assets of an exercise and the urls of its hosted videos
+func (s *API) withAssets(ctx context.Context, e *pbexrs.Exercise) *pbexrs.Exercise {
+	if e == nil || s.assets == nil {
+		return e
+	}
+	e.ImageAssets = s.resolveAssets(ctx, e.ImageIds)
+	for _, v := range e.Videos {
+		if v.Provider != pbexrs.VideoProvider_HOSTED {
+			continue
+		}
+		if a := s.resolveAssets(ctx, []string{v.AssetId}); len(a) > 0 {
+			v.Url = a[0].Url
+		}
+	}
+	return e
+}
+
+func (s *API) resolveAssets(ctx context.Context, ids []string) []*pbexrs.Asset {
+	var l []*pbexrs.Asset
+	for _, id := range ids {
+		a, err := s.assets.Resolve(ctx, id)
+		if err != nil {
+			//a missing asset must not break reading the exercise
+			ctxzap.Extract(ctx).Sugar().Warnf("could not resolve asset %v. Error was %v", id, err)
+			continue
+		}
+		l = append(l, UnmarshallAsset(a))
+	}
+	return l
+}
+
+//UnmarshallAsset converts an uploaded asset into a transport layer asset
+func UnmarshallAsset(a *assets.Asset) *pbexrs.Asset {
+	if a == nil {
+		return nil
+	}
+	return &pbexrs.Asset{
+		Id:           a.ID,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		Url:          a.URL,
+		ThumbnailUrl: a.ThumbnailURL,
+	}
+}
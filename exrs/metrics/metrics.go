@@ -0,0 +1,59 @@
+//Package metrics exposes prometheus metrics of the grpc server, the gateway and the storage
+package metrics
+
+import (
+	"net/http"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+//Metrics of a server process, kept in their own registry
+type Metrics struct {
+	Registry *prometheus.Registry
+	grpc     *grpc_prometheus.ServerMetrics
+	http     *prometheus.HistogramVec
+}
+
+//New creates the metrics, including the go runtime and process ones
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		grpc:     grpc_prometheus.NewServerMetrics(),
+		http: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "exrs_http_request_duration_seconds",
+			Help:    "Latency of the HTTP requests served by the gateway.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"code", "method"}),
+	}
+	m.grpc.EnableHandlingTimeHistogram()
+	m.Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.grpc,
+		m.http,
+	)
+	return m
+}
+
+//UnaryServerInterceptor counts every RPC by method and status code, and times it
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return m.grpc.UnaryServerInterceptor()
+}
+
+//InitializeMetrics reports every method of the server with zero values, call it once the services are registered
+func (m *Metrics) InitializeMetrics(s *grpc.Server) {
+	m.grpc.InitializeMetrics(s)
+}
+
+//InstrumentHandler times the requests served by h, by method and status code
+func (m *Metrics) InstrumentHandler(h http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(m.http, h)
+}
+
+//Handler serves the metrics in the prometheus exposition format, usually on /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+//Storage decorates an ExerciseStorage timing every operation
+type Storage struct {
+	storage.ExerciseStorage
+	ops *prometheus.HistogramVec
+}
+
+//NewStorage wraps s, registering the storage metrics
+func (m *Metrics) NewStorage(s storage.ExerciseStorage) *Storage {
+	ops := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "exrs_storage_operation_duration_seconds",
+		Help:    "Latency of the storage operations by operation and outcome.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "outcome"})
+	m.Registry.MustRegister(ops)
+	return &Storage{s, ops}
+}
+
+func (s *Storage) observe(op string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	s.ops.WithLabelValues(op, outcome).Observe(time.Since(start).Seconds())
+}
+
+//Create times the creation of an exercise
+func (s *Storage) Create(ctx context.Context, e *storage.Exercise) (r *storage.Exercise, err error) {
+	start := time.Now()
+	defer func() { s.observe("create", start, err) }()
+	return s.ExerciseStorage.Create(ctx, e)
+}
+
+//Read times reading an exercise
+func (s *Storage) Read(ctx context.Context, id string) (r *storage.Exercise, err error) {
+	start := time.Now()
+	defer func() { s.observe("read", start, err) }()
+	return s.ExerciseStorage.Read(ctx, id)
+}
+
+//Update times updating an exercise
+func (s *Storage) Update(ctx context.Context, id string, e *storage.Exercise) (r *storage.Exercise, err error) {
+	start := time.Now()
+	defer func() { s.observe("update", start, err) }()
+	return s.ExerciseStorage.Update(ctx, id, e)
+}
+
+//Delete times deleting an exercise
+func (s *Storage) Delete(ctx context.Context, id string) (ok bool, err error) {
+	start := time.Now()
+	defer func() { s.observe("delete", start, err) }()
+	return s.ExerciseStorage.Delete(ctx, id)
+}
+
+//List times listing exercises
+func (s *Storage) List(ctx context.Context, f storage.Filter) (l []*storage.Exercise, err error) {
+	start := time.Now()
+	defer func() { s.observe("list", start, err) }()
+	return s.ExerciseStorage.List(ctx, f)
+}
+
+//Ping times checking the backend
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	start := time.Now()
+	defer func() { s.observe("ping", start, err) }()
+	return s.ExerciseStorage.Ping(ctx)
+}
@@ -0,0 +1,39 @@
+// +build unit
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStorage struct {
+	storage.ExerciseStorage
+	err error
+}
+
+func (f fakeStorage) Read(ctx context.Context, id string) (*storage.Exercise, error) {
+	return &storage.Exercise{Id: id}, f.err
+}
+
+func TestStorage(t *testing.T) {
+	m := New()
+	ok := m.NewStorage(fakeStorage{})
+	_, err := ok.Read(context.Background(), "id")
+	assert.NoError(t, err)
+	_, err = ok.Read(context.Background(), "id")
+	assert.NoError(t, err)
+	failing := &Storage{fakeStorage{err: errors.New("boom")}, ok.ops}
+	_, err = failing.Read(context.Background(), "id")
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(ok.ops))
+	n, err := testutil.GatherAndCount(m.Registry, "exrs_storage_operation_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n, "one series per outcome")
+}
@@ -0,0 +1,248 @@
+//Package migration applies versioned changes to the stored exercises, once, in order
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	//LockTTL bounds how long a runner that crashed keeps the others waiting. Runners renew the lock
+	//every third of it while migrations run
+	LockTTL = 10 * time.Minute
+	//LockRetry is how often a runner waiting for the lock tries again
+	LockRetry = time.Second
+)
+
+//ErrLocked is returned by Store.Lock while another runner holds the lock
+var ErrLocked = errors.New("migrations are locked by another runner")
+
+//Migration changes the stored data from the version before it to Version
+type Migration struct {
+	//Version orders the migrations, starting at 1
+	Version int
+	Name    string
+	Up      func(context.Context) error
+	//Down reverts Up, nil when it can not be reverted
+	Down func(context.Context) error
+}
+
+//Record of an applied migration
+type Record struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+//Store records the applied migrations of a backend and serializes the runners
+type Store interface {
+	//Lock takes the lock for owner until ttl passes or Unlock, ErrLocked when another owner holds it
+	Lock(ctx context.Context, owner string, ttl time.Duration) error
+	//Unlock releases the lock if owner holds it
+	Unlock(ctx context.Context, owner string) error
+	//Applied returns the applied migrations
+	Applied(ctx context.Context) ([]Record, error)
+	//Add records an applied migration
+	Add(ctx context.Context, r Record) error
+	//Remove forgets a migration reverted
+	Remove(ctx context.Context, version int) error
+}
+
+//Status of a migration
+type Status struct {
+	Migration
+	//Applied is nil when the migration is pending
+	Applied *time.Time
+}
+
+//Runner applies migrations recorded in a store
+type Runner struct {
+	store      Store
+	migrations []Migration
+	owner      string
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+//NewRunner checks the migrations have distinct positive versions
+func NewRunner(s Store, migrations []Migration) (*Runner, error) {
+	l := append([]Migration(nil), migrations...)
+	sort.Slice(l, func(i, j int) bool { return l[i].Version < l[j].Version })
+	for i, m := range l {
+		if m.Version <= 0 || m.Up == nil {
+			return nil, fmt.Errorf("migration %v %q needs a positive version and Up", m.Version, m.Name)
+		}
+		if i > 0 && l[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migrations %q and %q share version %v", l[i-1].Name, m.Name, m.Version)
+		}
+	}
+	host, _ := os.Hostname()
+	return &Runner{store: s, migrations: l, owner: fmt.Sprintf("%s/%d/%d", host, os.Getpid(), time.Now().UnixNano()), ttl: LockTTL, now: time.Now}, nil
+}
+
+//Latest is the version of the last migration, 0 without migrations
+func (r *Runner) Latest() int {
+	if len(r.migrations) == 0 {
+		return 0
+	}
+	return r.migrations[len(r.migrations)-1].Version
+}
+
+//Status lists every migration, applied or pending
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		s := Status{Migration: m}
+		if rec, ok := applied[m.Version]; ok {
+			at := rec.AppliedAt
+			s.Applied = &at
+		}
+		l = append(l, s)
+	}
+	return l, nil
+}
+
+//Up applies the pending migrations, see To
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	return r.To(ctx, r.Latest())
+}
+
+//To applies the pending migrations up to version and reverts the applied ones after it, holding
+//the lock. It returns the migrations run, in the order they ran, and stops at the first failure.
+//Nothing runs when a migration to revert has no Down. The run fails if the lock is lost, once
+//another runner took it or it could not be renewed before it expired
+func (r *Runner) To(ctx context.Context, version int) (ran []Migration, err error) {
+	if err := r.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.store.Unlock(context.Background(), r.owner)
+	ctx, release := r.hold(ctx)
+	defer func() {
+		if lost := release(); lost != nil {
+			err = lost
+		}
+	}()
+	return r.run(ctx, version)
+}
+
+//run applies and reverts the migrations for To, stopping before the next one when ctx is done
+func (r *Runner) run(ctx context.Context, version int) ([]Migration, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var down []Migration
+	for i := len(r.migrations) - 1; i >= 0; i-- {
+		m := r.migrations[i]
+		if _, ok := applied[m.Version]; ok && m.Version > version {
+			if m.Down == nil {
+				return nil, fmt.Errorf("migration %v %q can not be reverted", m.Version, m.Name)
+			}
+			down = append(down, m)
+		}
+	}
+	var ran []Migration
+	for _, m := range down {
+		if err := ctx.Err(); err != nil {
+			return ran, err
+		}
+		if err := m.Down(ctx); err != nil {
+			return ran, fmt.Errorf("reverting migration %v %q failed. Error was %v", m.Version, m.Name, err)
+		}
+		if err := r.store.Remove(ctx, m.Version); err != nil {
+			return ran, fmt.Errorf("could not record migration %v %q as reverted. Error was %v", m.Version, m.Name, err)
+		}
+		ran = append(ran, m)
+	}
+	for _, m := range r.migrations {
+		if _, ok := applied[m.Version]; ok || m.Version > version {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return ran, err
+		}
+		if err := m.Up(ctx); err != nil {
+			return ran, fmt.Errorf("migration %v %q failed. Error was %v", m.Version, m.Name, err)
+		}
+		if err := r.store.Add(ctx, Record{Version: m.Version, Name: m.Name, AppliedAt: r.now()}); err != nil {
+			return ran, fmt.Errorf("could not record migration %v %q as applied. Error was %v", m.Version, m.Name, err)
+		}
+		ran = append(ran, m)
+	}
+	return ran, nil
+}
+
+//lock waits for the lock until ctx is done
+func (r *Runner) lock(ctx context.Context) error {
+	for {
+		err := r.store.Lock(ctx, r.owner, r.ttl)
+		if !errors.Is(err, ErrLocked) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up waiting for the migration lock. Error was %v", ctx.Err())
+		case <-time.After(LockRetry):
+		}
+	}
+}
+
+//hold renews the lock every third of its ttl until release is called. The context returned is
+//canceled when the lock is lost, release then returns why
+func (r *Runner) hold(ctx context.Context) (context.Context, func() error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	var lost error
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(r.ttl / 3)
+		defer ticker.Stop()
+		renewed := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			err := r.store.Lock(ctx, r.owner, r.ttl)
+			if err == nil {
+				renewed = time.Now()
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			//failing renewals are retried while the lock is still ours
+			if errors.Is(err, ErrLocked) || time.Since(renewed) >= r.ttl {
+				lost = fmt.Errorf("lost the migration lock. Error was %v", err)
+				cancel()
+				return
+			}
+		}
+	}()
+	return ctx, func() error {
+		cancel()
+		<-done
+		return lost
+	}
+}
+
+func (r *Runner) applied(ctx context.Context) (map[int]Record, error) {
+	l, err := r.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the applied migrations. Error was %v", err)
+	}
+	applied := map[int]Record{}
+	for _, rec := range l {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
@@ -0,0 +1,251 @@
+// +build unit
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	mu      sync.Mutex
+	owner   string
+	expires time.Time
+	applied map[int]Record
+}
+
+func newMemStore() *memStore {
+	return &memStore{applied: map[int]Record{}}
+}
+
+func (s *memStore) Lock(_ context.Context, owner string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner != "" && s.owner != owner && time.Now().Before(s.expires) {
+		return ErrLocked
+	}
+	s.owner, s.expires = owner, time.Now().Add(ttl)
+	return nil
+}
+
+func (s *memStore) Unlock(_ context.Context, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner == owner {
+		s.owner = ""
+	}
+	return nil
+}
+
+func (s *memStore) Applied(context.Context) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var l []Record
+	for _, r := range s.applied {
+		l = append(l, r)
+	}
+	return l, nil
+}
+
+func (s *memStore) Add(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applied[r.Version] = r
+	return nil
+}
+
+func (s *memStore) Remove(_ context.Context, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.applied, version)
+	return nil
+}
+
+//journal builds migrations logging what they run
+type journal struct {
+	log []string
+}
+
+func (j *journal) migration(version int, name string, reversible bool) Migration {
+	m := Migration{Version: version, Name: name, Up: func(context.Context) error {
+		j.log = append(j.log, "up "+name)
+		return nil
+	}}
+	if reversible {
+		m.Down = func(context.Context) error {
+			j.log = append(j.log, "down "+name)
+			return nil
+		}
+	}
+	return m
+}
+
+func versions(l []Migration) []int {
+	v := []int{}
+	for _, m := range l {
+		v = append(v, m.Version)
+	}
+	return v
+}
+
+func TestRunner(t *testing.T) {
+	testCases := []struct {
+		name    string
+		applied []int
+		to      int
+		ran     []int
+		log     []string
+		err     bool
+	}{
+		{"up from scratch", nil, 3, []int{1, 2, 3}, []string{"up one", "up two", "up three"}, false},
+		{"up to a version", nil, 2, []int{1, 2}, []string{"up one", "up two"}, false},
+		{"up is a no-op when applied", []int{1, 2, 3}, 3, []int{}, nil, false},
+		{"fills the gaps", []int{1, 3}, 3, []int{2}, []string{"up two"}, false},
+		{"down in reverse order", []int{1, 2, 3}, 1, []int{3, 2}, []string{"down three", "down two"}, false},
+		{"down refuses irreversible", []int{1, 2, 3}, 0, nil, nil, true},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			j := &journal{}
+			s := newMemStore()
+			for _, v := range tc.applied {
+				s.applied[v] = Record{Version: v}
+			}
+			r, err := NewRunner(s, []Migration{j.migration(3, "three", true), j.migration(1, "one", false), j.migration(2, "two", true)})
+			require.NoError(t, err)
+			ran, err := r.To(context.Background(), tc.to)
+			assert.Equal(t, tc.err, err != nil, "%v", err)
+			if tc.err {
+				return
+			}
+			assert.Equal(t, tc.ran, versions(ran))
+			assert.Equal(t, tc.log, j.log)
+			status, err := r.Status(context.Background())
+			require.NoError(t, err)
+			for _, st := range status {
+				assert.Equal(t, st.Version <= tc.to, st.Applied != nil, "version %v", st.Version)
+			}
+			assert.Empty(t, s.owner, "the lock is released")
+		})
+	}
+}
+
+func TestRunnerFailure(t *testing.T) {
+	s := newMemStore()
+	j := &journal{}
+	failing := Migration{Version: 2, Name: "failing", Up: func(context.Context) error { return errors.New("boom") }}
+	r, err := NewRunner(s, []Migration{j.migration(1, "one", true), failing, j.migration(3, "three", true)})
+	require.NoError(t, err)
+	ran, err := r.Up(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, versions(ran))
+	assert.Contains(t, s.applied, 1)
+	assert.NotContains(t, s.applied, 2)
+	assert.NotContains(t, s.applied, 3)
+}
+
+func TestRunnerLocked(t *testing.T) {
+	s := newMemStore()
+	s.Lock(context.Background(), "someone else", time.Hour)
+	r, err := NewRunner(s, []Migration{(&journal{}).migration(1, "one", true)})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = r.Up(ctx)
+	assert.Error(t, err)
+	assert.Empty(t, s.applied)
+}
+
+func TestRunnerRenewsLock(t *testing.T) {
+	s := newMemStore()
+	var taken error
+	slow := Migration{Version: 1, Name: "slow", Up: func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		taken = s.Lock(ctx, "someone else", time.Hour)
+		return nil
+	}}
+	r, err := NewRunner(s, []Migration{slow})
+	require.NoError(t, err)
+	r.ttl = 30 * time.Millisecond
+	_, err = r.Up(context.Background())
+	require.NoError(t, err)
+	assert.True(t, errors.Is(taken, ErrLocked), "the lock outlives its ttl while migrations run")
+	assert.Contains(t, s.applied, 1)
+}
+
+//renewals is a store failing every Lock after the first
+type renewals struct {
+	*memStore
+	calls int
+	err   error
+}
+
+func (s *renewals) Lock(ctx context.Context, owner string, ttl time.Duration) error {
+	s.calls++
+	if s.calls > 1 {
+		return s.err
+	}
+	return s.memStore.Lock(ctx, owner, ttl)
+}
+
+func TestRunnerLostLock(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"taken by another runner", ErrLocked},
+		{"not renewed before it expired", errors.New("store down")},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := &renewals{memStore: newMemStore(), err: tc.err}
+			j := &journal{}
+			slow := Migration{Version: 1, Name: "slow", Up: func(context.Context) error {
+				time.Sleep(100 * time.Millisecond)
+				return nil
+			}}
+			r, err := NewRunner(s, []Migration{slow, j.migration(2, "two", true)})
+			require.NoError(t, err)
+			r.ttl = 30 * time.Millisecond
+			_, err = r.Up(context.Background())
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "lost the migration lock")
+			}
+			assert.Empty(t, j.log, "no migration runs once the lock is lost")
+			assert.NotContains(t, s.applied, 2)
+		})
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	up := func(context.Context) error { return nil }
+	testCases := []struct {
+		name       string
+		migrations []Migration
+		valid      bool
+	}{
+		{"none", nil, true},
+		{"distinct", []Migration{{Version: 2, Up: up}, {Version: 1, Up: up}}, true},
+		{"shared version", []Migration{{Version: 1, Up: up}, {Version: 1, Up: up}}, false},
+		{"zero version", []Migration{{Version: 0, Up: up}}, false},
+		{"no up", []Migration{{Version: 1}}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := NewRunner(newMemStore(), tc.migrations)
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+}
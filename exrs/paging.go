@@ -0,0 +1,35 @@
+package exrs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//pageTokenPrefix versions the tokens, clients must treat them as opaque
+const pageTokenPrefix = "o:"
+
+//pageToken encodes the offset of the next page
+func pageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.Itoa(offset)))
+}
+
+//pageOffset decodes a token returned by pageToken, the empty token is the first page
+func pageOffset(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil && strings.HasPrefix(string(b), pageTokenPrefix) {
+		var offset int
+		offset, err = strconv.Atoi(strings.TrimPrefix(string(b), pageTokenPrefix))
+		if err == nil && offset >= 0 {
+			return offset, nil
+		}
+	}
+	return 0, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid page_token %q", token))
+}
@@ -0,0 +1,92 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//sliceStorage lists exercises the way the storages page them
+type sliceStorage struct {
+	storage.ExerciseStorage
+	exercises []*storage.Exercise
+}
+
+func (s sliceStorage) List(_ context.Context, f storage.Filter) ([]*storage.Exercise, error) {
+	l := s.exercises
+	if f.Offset > len(l) {
+		f.Offset = len(l)
+	}
+	l = l[f.Offset:]
+	if f.Limit > 0 && f.Limit < len(l) {
+		l = l[:f.Limit]
+	}
+	return l, nil
+}
+
+func TestListPaging(t *testing.T) {
+	repo := sliceStorage{}
+	for i := 0; i < 5; i++ {
+		repo.exercises = append(repo.exercises, &storage.Exercise{Id: fmt.Sprint(i)})
+	}
+	api := &API{ExerciseStorage: repo}
+	var ids []string
+	req := &pbexrs.ListExercisesRequest{PageSize: 2}
+	for pages := 1; ; pages++ {
+		resp, err := api.ListExercises(context.Background(), req)
+		assert.NoError(t, err)
+		for _, e := range resp.Exercises {
+			ids = append(ids, e.Id)
+		}
+		if resp.NextPageToken == "" {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids)
+
+	all, err := api.ListExercises(context.Background(), &pbexrs.ListExercisesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, all.Exercises, 5)
+	assert.Empty(t, all.NextPageToken)
+
+	_, err = api.ListExercises(context.Background(), &pbexrs.ListExercisesRequest{PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPageToken(t *testing.T) {
+	testCases := []struct {
+		name   string
+		token  string
+		offset int
+		err    bool
+	}{
+		{"first page", "", 0, false},
+		{"round trip", pageToken(40), 40, false},
+		{"not base64", "!!", 0, true},
+		{"unknown version", "eDo0MA", 0, true},
+		{"negative", pageToken(-1), 0, true},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			offset, err := pageOffset(tc.token)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.offset, offset)
+		})
+	}
+}
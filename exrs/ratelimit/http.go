@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+//HTTPError writes the gateway errors like runtime.DefaultHTTPError, adding a Retry-After header
+//in seconds to the errors carrying a RetryInfo. ResourceExhausted already maps to 429
+func HTTPError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if delay, ok := RetryDelay(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(delay.Seconds())))))
+	}
+	runtime.DefaultHTTPError(ctx, mux, marshaler, w, r, err)
+}
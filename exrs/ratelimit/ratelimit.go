@@ -0,0 +1,177 @@
+//Package ratelimit limits the calls of each caller with token buckets
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	//KeyIdentity keys the buckets by the caller identity, falling back to its IP
+	KeyIdentity = "identity"
+	//KeyAPIKey keys the buckets by the verified bearer token of the call, falling back to its IP
+	KeyAPIKey = "api_key"
+	//KeyIP keys the buckets by the peer IP of the call
+	KeyIP = "ip"
+)
+
+//healthService is never limited, orchestrators probing it must not be turned away
+const healthService = "/grpc.health.v1.Health/"
+
+//Limit of a bucket
+type Limit struct {
+	//Rate of calls per second, calls are not limited when it is 0
+	Rate float64 `yaml:"rate"`
+	//Burst of calls allowed at once, the rate rounded up when 0
+	Burst int `yaml:"burst"`
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Max(1, math.Ceil(l.Rate)))
+}
+
+//Config of the limits, every caller gets its own bucket per method listed in Methods and one
+//shared by the other methods
+type Config struct {
+	//Key the buckets are keyed by: identity, api_key or ip
+	Key string `yaml:"key"`
+	//TrustForwardedFor takes the peer IP from the x-forwarded-for metadata the gateway sets.
+	//Only turn it on when clients cannot reach the gRPC server but through the gateway
+	TrustForwardedFor bool `yaml:"trust_forwarded_for"`
+	//Default limit of the methods missing from Methods
+	Default Limit `yaml:"default"`
+	//Methods limits by method name, ex: ListExercises or /pbexrs.ExerciseService/ListExercises
+	Methods map[string]Limit `yaml:"methods"`
+}
+
+//Validate tells whether c is usable
+func (c Config) Validate() error {
+	switch c.Key {
+	case "", KeyIdentity, KeyAPIKey, KeyIP:
+	default:
+		return fmt.Errorf("unknown rate limit key %q, expected %v, %v or %v", c.Key, KeyIdentity, KeyAPIKey, KeyIP)
+	}
+	limits := []Limit{c.Default}
+	for _, l := range c.Methods {
+		limits = append(limits, l)
+	}
+	for _, l := range limits {
+		if l.Rate < 0 || l.Burst < 0 {
+			return fmt.Errorf("rate limits can not be negative, got rate %v and burst %v", l.Rate, l.Burst)
+		}
+	}
+	return nil
+}
+
+//limit of method and the bucket it takes from
+func (c Config) limit(method string) (Limit, string) {
+	if l, ok := c.Methods[method]; ok {
+		return l, method
+	}
+	if l, ok := c.Methods[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return l, method
+	}
+	return c.Default, "*"
+}
+
+//caller names who makes the call for c.Key. Only the identities and tokens the auth interceptors
+//verified are trusted, any other caller could pick a new bucket on every call
+func (c Config) caller(ctx context.Context) string {
+	id, verified := auth.FromContext(ctx)
+	verified = verified && id.Subject != "" && id.Subject != auth.Anonymous
+	if verified && (c.Key == "" || c.Key == KeyIdentity) {
+		return "subject:" + id.Subject
+	}
+	//the token interceptor set the identity of the token, certificates carry none
+	if verified && !id.Certificate && c.Key != KeyIP {
+		if token := auth.Bearer(ctx); token != "" {
+			//the store may be shared, it only sees a digest of the token
+			sum := sha256.Sum256([]byte(token))
+			return "key:" + hex.EncodeToString(sum[:])
+		}
+	}
+	return "ip:" + c.peerIP(ctx)
+}
+
+func (c Config) peerIP(ctx context.Context) string {
+	if c.TrustForwardedFor {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("x-forwarded-for"); len(v) > 0 {
+			//the gateway appends the address it was called from last
+			hops := strings.Split(v[len(v)-1], ",")
+			return strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+//UnaryServerInterceptor turns away the calls over their limit with ResourceExhausted and a
+//RetryInfo telling when to retry. Calls go through when the store fails, it must not take the
+//service down. It goes after the auth interceptors to see the caller identity
+func UnaryServerInterceptor(c Config, s Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthService) {
+			return handler(ctx, req)
+		}
+		limit, bucket := c.limit(info.FullMethod)
+		if limit.Rate == 0 {
+			return handler(ctx, req)
+		}
+		ok, wait, err := s.Take(ctx, bucket+"|"+c.caller(ctx), limit, time.Now())
+		if err != nil {
+			ctxzap.Extract(ctx).Warn("rate limit store failed, letting the call through", zap.Error(err))
+			return handler(ctx, req)
+		}
+		if !ok {
+			return nil, exhausted(wait)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func exhausted(wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+//RetryDelay returns the delay of the RetryInfo details of err, if any
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			delay, err := ptypes.Duration(info.GetRetryDelay())
+			return delay, err == nil
+		}
+	}
+	return 0, false
+}
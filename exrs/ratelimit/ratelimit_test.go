@@ -0,0 +1,209 @@
+// +build unit
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Now()
+	limit := Limit{Rate: 2, Burst: 3}
+	testCases := []struct {
+		name    string
+		takes   []time.Duration
+		allowed []bool
+		wait    time.Duration
+	}{
+		{"burst", []time.Duration{0, 0, 0}, []bool{true, true, true}, 0},
+		{"over the burst", []time.Duration{0, 0, 0, 0}, []bool{true, true, true, false}, 500 * time.Millisecond},
+		{"refilled", []time.Duration{0, 0, 0, 0, 500 * time.Millisecond}, []bool{true, true, true, false, true}, 0},
+		{"refill capped at the burst", []time.Duration{0, time.Hour, time.Hour, time.Hour, time.Hour}, []bool{true, true, true, true, false}, 500 * time.Millisecond},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewMemoryStore()
+			var wait time.Duration
+			for i, at := range tc.takes {
+				ok, w, err := s.Take(context.Background(), "k", limit, now.Add(at))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.allowed[i], ok, "take %v", i)
+				wait = w
+			}
+			assert.Equal(t, tc.wait, wait)
+		})
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryStore()
+	limit := Limit{Rate: 1}
+	s.Take(context.Background(), "idle", limit, now)
+	s.Take(context.Background(), "busy", Limit{Rate: 0.001, Burst: 2}, now)
+	s.Take(context.Background(), "other", limit, now.Add(2*sweepInterval))
+	assert.NotContains(t, s.buckets, "idle")
+	assert.Contains(t, s.buckets, "busy")
+	assert.Contains(t, s.buckets, "other")
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit, time.Time) (bool, time.Duration, error) {
+	return false, 0, errors.New("store down")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const list = "/pbexrs.ExerciseService/ListExercises"
+	const read = "/pbexrs.ExerciseService/ReadExercise"
+	ip := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 1234}})
+	}
+	token := func(ctx context.Context, token string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	subject := func(ctx context.Context, subject string) context.Context {
+		return auth.NewContext(ctx, auth.Identity{Subject: subject})
+	}
+	forwarded := func(ctx context.Context, fwd string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", fwd))
+	}
+	one := Limit{Rate: 1, Burst: 1}
+	type call struct {
+		ctx    context.Context
+		method string
+	}
+	testCases := []struct {
+		name   string
+		config Config
+		store  Store
+		calls  []call
+		codes  []codes.Code
+	}{
+		{"no limits", Config{}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.1"), list}},
+			[]codes.Code{codes.OK, codes.OK}},
+		{"default limit", Config{Default: one}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.1"), read}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted}},
+		{"per peer ip", Config{Default: one}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.2"), list}},
+			[]codes.Code{codes.OK, codes.OK}},
+		{"per method", Config{Methods: map[string]Limit{"ListExercises": one}}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.1"), read}, {ip("10.0.0.1"), list}},
+			[]codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"full method name", Config{Default: Limit{Rate: 100}, Methods: map[string]Limit{list: one}}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.1"), read}, {ip("10.0.0.1"), list}},
+			[]codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"per api key", Config{Key: KeyAPIKey, Default: one}, NewMemoryStore(),
+			[]call{{subject(token(ip("10.0.0.1"), "a"), "ops"), list}, {subject(token(ip("10.0.0.1"), "b"), "dev"), list}, {subject(token(ip("10.0.0.2"), "a"), "ops"), list}},
+			[]codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"rotating unverified tokens", Config{Default: one}, NewMemoryStore(),
+			[]call{{token(ip("10.0.0.1"), "x1"), list}, {token(ip("10.0.0.1"), "x2"), list}, {token(ip("10.0.0.1"), "x3"), list}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted, codes.ResourceExhausted}},
+		{"rotating unverified api keys", Config{Key: KeyAPIKey, Default: one}, NewMemoryStore(),
+			[]call{{token(ip("10.0.0.1"), "x1"), list}, {token(ip("10.0.0.1"), "x2"), list}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted}},
+		{"per identity", Config{Default: one}, NewMemoryStore(),
+			[]call{{subject(token(ip("10.0.0.1"), "a"), "ops"), list}, {subject(token(ip("10.0.0.2"), "b"), "ops"), list}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted}},
+		{"ip ignores identity", Config{Key: KeyIP, Default: one}, NewMemoryStore(),
+			[]call{{subject(ip("10.0.0.1"), "ops"), list}, {subject(ip("10.0.0.1"), "dev"), list}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted}},
+		{"forwarded for ignored", Config{Default: one}, NewMemoryStore(),
+			[]call{{forwarded(ip("10.0.0.1"), "1.1.1.1"), list}, {forwarded(ip("10.0.0.1"), "2.2.2.2"), list}},
+			[]codes.Code{codes.OK, codes.ResourceExhausted}},
+		{"forwarded for trusted", Config{TrustForwardedFor: true, Default: one}, NewMemoryStore(),
+			[]call{{forwarded(ip("10.0.0.1"), "9.9.9.9, 1.1.1.1"), list}, {forwarded(ip("10.0.0.1"), "2.2.2.2"), list}, {forwarded(ip("10.0.0.1"), "1.1.1.1"), list}},
+			[]codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}},
+		{"health checks", Config{Default: one}, NewMemoryStore(),
+			[]call{{ip("10.0.0.1"), "/grpc.health.v1.Health/Check"}, {ip("10.0.0.1"), "/grpc.health.v1.Health/Check"}},
+			[]codes.Code{codes.OK, codes.OK}},
+		{"failing store", Config{Default: one}, failingStore{},
+			[]call{{ip("10.0.0.1"), list}, {ip("10.0.0.1"), list}},
+			[]codes.Code{codes.OK, codes.OK}},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			interceptor := UnaryServerInterceptor(tc.config, tc.store)
+			for i, c := range tc.calls {
+				_, err := interceptor(c.ctx, nil, &grpc.UnaryServerInfo{FullMethod: c.method},
+					func(ctx context.Context, req interface{}) (interface{}, error) {
+						return nil, nil
+					})
+				assert.Equal(t, tc.codes[i], status.Code(err), "call %v", i)
+				if status.Code(err) == codes.ResourceExhausted {
+					delay, ok := RetryDelay(err)
+					assert.True(t, ok)
+					assert.True(t, delay > 0 && delay <= time.Second, "delay %v", delay)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config Config
+		valid  bool
+	}{
+		{"empty", Config{}, true},
+		{"limits", Config{Key: KeyIP, Default: Limit{Rate: 10, Burst: 20}, Methods: map[string]Limit{"ListExercises": {Rate: 1}}}, true},
+		{"unknown key", Config{Key: "cookie"}, false},
+		{"negative rate", Config{Methods: map[string]Limit{"ListExercises": {Rate: -1}}}, false},
+		{"negative burst", Config{Default: Limit{Rate: 1, Burst: -1}}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.config.Validate()
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		code       int
+		retryAfter string
+	}{
+		{"exhausted", exhausted(1500 * time.Millisecond), http.StatusTooManyRequests, "2"},
+		{"under a second", exhausted(10 * time.Millisecond), http.StatusTooManyRequests, "1"},
+		{"other errors", status.Error(codes.NotFound, "no such exercise"), http.StatusNotFound, ""},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			mux := runtime.NewServeMux(runtime.WithProtoErrorHandler(HTTPError))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/exercises", nil)
+			HTTPError(context.Background(), mux, &runtime.JSONPb{}, w, r, tc.err)
+			assert.Equal(t, tc.code, w.Code)
+			assert.Equal(t, tc.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
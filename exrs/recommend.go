@@ -0,0 +1,53 @@
+package exrs
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/recommend"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+)
+
+//WithEquipment recommends exercises knowing the categories named in equipment are the equipment
+//they need. Without it recommend.DefaultEquipment is used
+func WithEquipment(equipment []string) Option {
+	return func(s *API) {
+		s.recommender = recommend.New(equipment)
+	}
+}
+
+//RecommendExercises picks the exercises working best the muscle groups asked for, with the
+//equipment at hand and at the difficulty wanted, explaining every pick
+func (s *API) RecommendExercises(ctx context.Context, req *pbexrs.RecommendExercisesRequest) (*pbexrs.RecommendExercisesResponse, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	log.Debugf("recommending exercises for %v with %v", req.GetMuscleGroups(), req.GetEquipment())
+	//the difficulty asked for is the hardest wanted and exercises of unknown difficulty are picked too,
+	//storage only filters the exact difficulty so the scan is bounded instead
+	l, err := s.ExerciseStorage.List(ctx, storage.Filter{Limit: maxCandidates + 1})
+	if err != nil {
+		log.Warnf("failed to list exercises to recommend. Error was %v", err)
+		return &pbexrs.RecommendExercisesResponse{}, err
+	}
+	if len(l) > maxCandidates {
+		log.Warnf("recommending among the first %d exercises only", maxCandidates)
+		l = l[:maxCandidates]
+	}
+	picks := s.recommender.Recommend(l, recommend.Query{
+		Targets:    req.GetMuscleGroups(),
+		Equipment:  req.GetEquipment(),
+		Difficulty: enumToStorage(int32(req.GetDifficulty()), pbexrs.Difficulty_name),
+		Exclude:    req.GetExcludeIds(),
+		Limit:      int(req.GetLimit()),
+	})
+	recommendations := make([]*pbexrs.Recommendation, 0, len(picks))
+	for _, p := range picks {
+		recommendations = append(recommendations, &pbexrs.Recommendation{
+			Exercise: s.withAssets(ctx, UnmarshallExercise(p.Exercise)),
+			Score:    p.Score,
+			Reasons:  p.Reasons,
+		})
+	}
+	log.Debugf("recommended %d of %d exercises", len(recommendations), len(l))
+	return &pbexrs.RecommendExercisesResponse{Recommendations: recommendations}, nil
+}
@@ -0,0 +1,239 @@
+//Package recommend picks the exercises working the muscle groups asked for, with the equipment at
+//hand, explaining every pick
+package recommend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maxvw8/exercise_lib/exrs/duplicates"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+)
+
+//DefaultEquipment are the categories telling the equipment an exercise needs when none are configured
+var DefaultEquipment = []string{"barbell", "dumbbell", "kettlebell", "cable", "machine", "resistance band",
+	"pull-up bar", "bench", "medicine ball"}
+
+//DefaultLimit of the recommendations when a query has none
+const DefaultLimit = 5
+
+//weights of the parts of a score, they add up to 1
+const (
+	coverageWeight   = 0.6
+	difficultyWeight = 0.25
+	equipmentWeight  = 0.15
+)
+
+//levels orders the difficulties as stored
+var levels = map[string]int{"beginner": 1, "intermediate": 2, "advanced": 3}
+
+//Query of recommendations
+type Query struct {
+	//Targets are the muscle groups to work, matched against the muscle groups and the muscles of the exercises
+	Targets []string
+	//Equipment at hand, exercises needing other equipment are left out. Any equipment when empty
+	Equipment []string
+	//Difficulty the exercises should have, harder ones are left out. Any difficulty when empty
+	Difficulty string
+	//Exclude are the ids of exercises not to recommend, like the ones done recently
+	Exclude []string
+	//Limit of recommendations, DefaultLimit when not positive
+	Limit int
+}
+
+//Recommendation of an exercise, with its score from 0 to 1 and why it was picked
+type Recommendation struct {
+	Exercise *storage.Exercise
+	Score    float64
+	Reasons  []string
+}
+
+//Recommender knows which categories are equipment
+type Recommender struct {
+	//equipment maps the normalized names of the equipment to their names
+	equipment map[string]string
+}
+
+//New creates a recommender treating the categories named in equipment as the equipment exercises need
+func New(equipment []string) *Recommender {
+	r := &Recommender{equipment: make(map[string]string, len(equipment))}
+	for _, e := range equipment {
+		r.equipment[duplicates.Normalize(e)] = e
+	}
+	return r
+}
+
+//candidate is an exercise that can be recommended, with the parts of its score that don't depend
+//on the other picks
+type candidate struct {
+	exercise   *storage.Exercise
+	targets    []string
+	base       float64
+	reasons    []string
+	needs      []string
+	difficulty int
+}
+
+//Recommend picks up to the limit of exercises of l, one at a time: each pick is the exercise working
+//best the targets the previous picks worked the least, so the recommendations cover all of them.
+//Ties go to the easiest exercise, then by name
+func (r *Recommender) Recommend(l []*storage.Exercise, q Query) []Recommendation {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	targets := make(map[string]string)
+	for _, t := range q.Targets {
+		targets[duplicates.Normalize(t)] = t
+	}
+	var candidates []*candidate
+	for _, e := range l {
+		if c, ok := r.candidate(e, q, targets); ok {
+			candidates = append(candidates, c)
+		}
+	}
+	worked := make(map[string]int)
+	var picks []Recommendation
+	for len(picks) < limit && len(candidates) > 0 {
+		best, bestScore := -1, 0.0
+		for i, c := range candidates {
+			score := c.base + coverageWeight*coverage(c.targets, worked, len(targets))
+			if best < 0 || score > bestScore+1e-9 || (score > bestScore-1e-9 && before(c, candidates[best])) {
+				best, bestScore = i, score
+			}
+		}
+		c := candidates[best]
+		candidates = append(candidates[:best], candidates[best+1:]...)
+		reasons := append([]string{works(c.targets, worked)}, c.reasons...)
+		for _, t := range c.targets {
+			worked[t]++
+		}
+		picks = append(picks, Recommendation{Exercise: c.exercise, Score: bestScore, Reasons: reasons})
+	}
+	return picks
+}
+
+//candidate scores the difficulty and equipment of e, ok is false when e can't be recommended
+func (r *Recommender) candidate(e *storage.Exercise, q Query, targets map[string]string) (*candidate, bool) {
+	for _, id := range q.Exclude {
+		if id == e.Id {
+			return nil, false
+		}
+	}
+	c := &candidate{exercise: e, difficulty: levels[strings.ToLower(e.Difficulty)]}
+	seen := make(map[string]bool)
+	for _, g := range append(append([]string(nil), e.MuscleGroups...), e.Muscles...) {
+		if t, ok := targets[duplicates.Normalize(g)]; ok && !seen[t] {
+			seen[t] = true
+			c.targets = append(c.targets, t)
+		}
+	}
+	if len(c.targets) == 0 {
+		return nil, false
+	}
+	for _, category := range e.Categories {
+		if name, ok := r.equipment[duplicates.Normalize(category)]; ok {
+			c.needs = append(c.needs, name)
+		}
+	}
+	equipment, reason, ok := r.equipmentScore(c.needs, q.Equipment)
+	if !ok {
+		return nil, false
+	}
+	c.base += equipmentWeight * equipment
+	c.reasons = append(c.reasons, reason)
+	difficulty, reason, ok := difficultyScore(c.difficulty, levels[strings.ToLower(q.Difficulty)])
+	if !ok {
+		return nil, false
+	}
+	c.base += difficultyWeight * difficulty
+	if reason != "" {
+		c.reasons = append(c.reasons, reason)
+	}
+	return c, true
+}
+
+//equipmentScore of an exercise needing the equipment in needs. Using the equipment at hand scores
+//best, needing none a bit less, needing any other leaves the exercise out
+func (r *Recommender) equipmentScore(needs, available []string) (float64, string, bool) {
+	if len(needs) == 0 {
+		return 0.7, "needs no equipment", true
+	}
+	list := strings.Join(needs, ", ")
+	if len(available) == 0 {
+		return 1, "uses " + list, true
+	}
+	at := make(map[string]bool, len(available))
+	for _, a := range available {
+		at[duplicates.Normalize(a)] = true
+	}
+	for _, n := range needs {
+		if !at[duplicates.Normalize(n)] {
+			return 0, "", false
+		}
+	}
+	return 1, "uses only available equipment: " + list, true
+}
+
+//difficultyScore of an exercise of the level given when the asked level is wanted. Harder exercises
+//are left out, easier ones lose points with every level. Either level is 0 when unknown
+func difficultyScore(level, wanted int) (float64, string, bool) {
+	switch {
+	case wanted == 0:
+		return 1, "", true
+	case level == 0:
+		return 0.5, "difficulty unknown", true
+	case level > wanted:
+		return 0, "", false
+	case level == wanted:
+		return 1, "matches the difficulty asked for", true
+	}
+	return 1 - 0.4*float64(wanted-level), "easier than the difficulty asked for", true
+}
+
+//rank orders the levels for ties, unknown after the hardest
+func rank(level int) int {
+	if level == 0 {
+		return len(levels) + 1
+	}
+	return level
+}
+
+//coverage of the targets worked by an exercise, those worked by fewer picks count more
+func coverage(targets []string, worked map[string]int, all int) float64 {
+	var sum float64
+	for _, t := range targets {
+		sum += 1 / float64(1+worked[t])
+	}
+	return sum / float64(all)
+}
+
+//works explains the targets worked by an exercise, telling those no previous pick worked
+func works(targets []string, worked map[string]int) string {
+	var fresh []string
+	for _, t := range targets {
+		if worked[t] == 0 {
+			fresh = append(fresh, t)
+		}
+	}
+	s := "works " + strings.Join(targets, ", ")
+	switch {
+	case len(fresh) == 0 || len(worked) == 0:
+	case len(fresh) == len(targets):
+		s += ", missed by the previous picks"
+	default:
+		s += fmt.Sprintf(", adding %s to the previous picks", strings.Join(fresh, ", "))
+	}
+	return s
+}
+
+//before breaks ties, easiest first then by name. Exercises of unknown difficulty come last
+func before(a, b *candidate) bool {
+	if la, lb := rank(a.difficulty), rank(b.difficulty); la != lb {
+		return la < lb
+	}
+	if a.exercise.Name != b.exercise.Name {
+		return a.exercise.Name < b.exercise.Name
+	}
+	return a.exercise.Id < b.exercise.Id
+}
@@ -0,0 +1,86 @@
+// +build unit
+
+package recommend
+
+import (
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var catalog = []*storage.Exercise{
+	{Id: "1", Name: "Barbell Row", MuscleGroups: []string{"back"}, Muscles: []string{"biceps"}, Categories: []string{"strength", "barbell"}, Difficulty: "intermediate"},
+	{Id: "2", Name: "Dumbbell Row", MuscleGroups: []string{"back"}, Muscles: []string{"biceps"}, Categories: []string{"strength", "Dumbbells"}, Difficulty: "beginner"},
+	{Id: "3", Name: "Hammer Curl", MuscleGroups: []string{"arms"}, Muscles: []string{"biceps"}, Categories: []string{"dumbbell"}, Difficulty: "beginner"},
+	{Id: "4", Name: "Pull Up", MuscleGroups: []string{"Back", "arms"}, Muscles: []string{"biceps", "lats"}, Categories: []string{"pull-up bar"}, Difficulty: "advanced"},
+	{Id: "5", Name: "Superman", MuscleGroups: []string{"back"}, Categories: []string{"bodyweight"}, Difficulty: "beginner"},
+	{Id: "6", Name: "Squat", MuscleGroups: []string{"legs"}, Difficulty: "beginner"},
+	{Id: "7", Name: "Renegade Row", MuscleGroups: []string{"back", "core"}, Categories: []string{"dumbbell"}},
+}
+
+func names(l []Recommendation) []string {
+	var n []string
+	for _, r := range l {
+		n = append(n, r.Exercise.Name)
+	}
+	return n
+}
+
+func TestRecommend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    Query
+		expected []string
+	}{
+		{"every equipment", Query{Targets: []string{"back", "biceps"}}, []string{"Dumbbell Row", "Barbell Row", "Pull Up", "Hammer Curl", "Renegade Row"}},
+		{"dumbbells only", Query{Targets: []string{"back", "biceps"}, Equipment: []string{"dumbbells"}}, []string{"Dumbbell Row", "Hammer Curl", "Renegade Row", "Superman"}},
+		{"excluded", Query{Targets: []string{"back", "biceps"}, Equipment: []string{"dumbbell"}, Exclude: []string{"2"}}, []string{"Hammer Curl", "Renegade Row", "Superman"}},
+		{"beginner", Query{Targets: []string{"back"}, Difficulty: "beginner", Limit: 2}, []string{"Dumbbell Row", "Superman"}},
+		{"intermediate", Query{Targets: []string{"back"}, Difficulty: "intermediate", Limit: 3}, []string{"Barbell Row", "Dumbbell Row", "Renegade Row"}},
+		{"nothing works the targets", Query{Targets: []string{"calves"}}, nil},
+	}
+	r := New(DefaultEquipment)
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, names(r.Recommend(catalog, tc.query)))
+		})
+	}
+}
+
+func TestRecommendCoversTargets(t *testing.T) {
+	l := []*storage.Exercise{
+		{Id: "1", Name: "Deadlift", MuscleGroups: []string{"back"}},
+		{Id: "2", Name: "Lat Pulldown", MuscleGroups: []string{"back"}},
+		{Id: "3", Name: "Curl", MuscleGroups: []string{"biceps"}},
+		{Id: "4", Name: "Chin Up", MuscleGroups: []string{"back", "biceps"}, Difficulty: "advanced"},
+		{Id: "5", Name: "Reverse Curl", MuscleGroups: []string{"biceps", "forearms"}},
+	}
+	picks := New(nil).Recommend(l, Query{Targets: []string{"back", "biceps"}, Limit: 2})
+	assert.Equal(t, []string{"Chin Up", "Curl"}, names(picks), "working both targets comes first")
+	picks = New(nil).Recommend(l[:3], Query{Targets: []string{"back", "biceps"}, Limit: 2})
+	assert.Equal(t, []string{"Curl", "Deadlift"}, names(picks), "the second pick works what the first missed")
+	assert.Equal(t, "works back, missed by the previous picks", picks[1].Reasons[0])
+	picks = New(nil).Recommend(l, Query{Targets: []string{"back", "biceps", "forearms"}, Exclude: []string{"4"}, Limit: 2})
+	assert.Equal(t, []string{"Reverse Curl", "Deadlift"}, names(picks))
+	assert.Equal(t, "works back, missed by the previous picks", picks[1].Reasons[0])
+	picks = New(nil).Recommend(l, Query{Targets: []string{"back", "biceps", "forearms"}, Limit: 2})
+	assert.Equal(t, []string{"Chin Up", "Reverse Curl"}, names(picks))
+	assert.Equal(t, "works biceps, forearms, adding forearms to the previous picks", picks[1].Reasons[0])
+}
+
+func TestRecommendReasons(t *testing.T) {
+	picks := New(DefaultEquipment).Recommend(catalog, Query{Targets: []string{"back", "biceps"}, Equipment: []string{"dumbbell"}, Difficulty: "beginner", Limit: 1})
+	if assert.Len(t, picks, 1) {
+		assert.Equal(t, "Dumbbell Row", picks[0].Exercise.Name)
+		assert.Equal(t, []string{"works back, biceps", "uses only available equipment: dumbbell", "matches the difficulty asked for"}, picks[0].Reasons)
+		assert.InDelta(t, 1, picks[0].Score, 1e-9)
+	}
+	picks = New(DefaultEquipment).Recommend(catalog, Query{Targets: []string{"back"}, Difficulty: "advanced", Exclude: []string{"1", "2", "4", "7"}})
+	if assert.Len(t, picks, 1) {
+		assert.Equal(t, []string{"works back", "needs no equipment", "easier than the difficulty asked for"}, picks[0].Reasons)
+		assert.InDelta(t, 0.6+0.15*0.7+0.25*0.2, picks[0].Score, 1e-9)
+	}
+}
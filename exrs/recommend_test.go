@@ -0,0 +1,91 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommendExercises(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	ids := make(map[string]string)
+	for _, e := range []*storage.Exercise{
+		{Name: "Dumbbell Row", MuscleGroups: []string{"back"}, Muscles: []string{"biceps"}, Categories: []string{"dumbbell"}, Difficulty: "beginner"},
+		{Name: "Barbell Row", MuscleGroups: []string{"back"}, Muscles: []string{"biceps"}, Categories: []string{"barbell"}, Difficulty: "beginner"},
+		{Name: "Hammer Curl", MuscleGroups: []string{"biceps"}, Categories: []string{"dumbbell"}, Difficulty: "beginner"},
+		{Name: "Pull Up", MuscleGroups: []string{"back", "biceps"}, Categories: []string{"trx"}, Difficulty: "beginner"},
+		{Name: "Squat", MuscleGroups: []string{"legs"}, Categories: []string{"dumbbell"}},
+	} {
+		created, err := repo.Create(ctx, e)
+		require.NoError(t, err)
+		ids[created.Name] = created.Id
+	}
+	names := func(resp *pbexrs.RecommendExercisesResponse) []string {
+		var l []string
+		for _, r := range resp.Recommendations {
+			l = append(l, r.Exercise.Name)
+		}
+		return l
+	}
+
+	api, _ := Server(repo, repo)
+	resp, err := api.RecommendExercises(ctx, &pbexrs.RecommendExercisesRequest{
+		MuscleGroups: []string{"back", "biceps"},
+		Equipment:    []string{"dumbbells"},
+		Difficulty:   pbexrs.Difficulty_BEGINNER,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dumbbell Row", "Pull Up", "Hammer Curl"}, names(resp), "trx is not known as equipment")
+	assert.Equal(t, []string{"works back, biceps", "uses only available equipment: dumbbell", "matches the difficulty asked for"},
+		resp.Recommendations[0].Reasons)
+	assert.InDelta(t, 1, resp.Recommendations[0].Score, 1e-9)
+
+	resp, err = api.RecommendExercises(ctx, &pbexrs.RecommendExercisesRequest{
+		MuscleGroups: []string{"back", "biceps"},
+		Equipment:    []string{"dumbbell"},
+		ExcludeIds:   []string{ids["Dumbbell Row"]},
+		Limit:        1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Pull Up"}, names(resp))
+
+	api, _ = Server(repo, repo, WithEquipment([]string{"dumbbell", "barbell", "trx"}))
+	resp, err = api.RecommendExercises(ctx, &pbexrs.RecommendExercisesRequest{MuscleGroups: []string{"back", "biceps"}, Equipment: []string{"dumbbell"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Dumbbell Row", "Hammer Curl"}, names(resp), "pull ups need a trx")
+}
+
+//listing records the filters exercises are listed with
+type listing struct {
+	storage.ExerciseStorage
+	filters []storage.Filter
+}
+
+func (l *listing) List(ctx context.Context, f storage.Filter) ([]*storage.Exercise, error) {
+	l.filters = append(l.filters, f)
+	return l.ExerciseStorage.List(ctx, f)
+}
+
+func TestCatalogScansAreBounded(t *testing.T) {
+	ctx := context.Background()
+	bolt := openBolt(t)
+	repo := &listing{ExerciseStorage: bolt}
+	api, _ := Server(repo, bolt)
+
+	_, err := api.RecommendExercises(ctx, &pbexrs.RecommendExercisesRequest{MuscleGroups: []string{"back"}, Difficulty: pbexrs.Difficulty_BEGINNER})
+	require.NoError(t, err)
+	_, err = api.FindDuplicateExercises(ctx, &pbexrs.FindDuplicateExercisesRequest{})
+	require.NoError(t, err)
+	if assert.Len(t, repo.filters, 2) {
+		for _, f := range repo.filters {
+			assert.Equal(t, storage.Filter{Limit: maxCandidates + 1}, f)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package exrs
+
+import (
+	"github.com/maxvw8/exercise_lib/exrs/references"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+)
+
+//WithReferences checks the references registered in r before deleting exercises. Without it
+//nothing references them
+func WithReferences(r *references.Registry) Option {
+	return func(s *API) {
+		s.references = r
+	}
+}
+
+//deletePolicy converts the policy of a delete request, restrict unless specified
+func deletePolicy(p pbexrs.DeletePolicy) references.Policy {
+	switch p {
+	case pbexrs.DeletePolicy_CASCADE:
+		return references.Cascade
+	case pbexrs.DeletePolicy_NULLIFY:
+		return references.Nullify
+	}
+	return references.Restrict
+}
@@ -0,0 +1,188 @@
+//Package references lets the subsystems holding exercise ids, like workouts or programs, declare
+//them so deleting an exercise never leaves them dangling
+package references
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Policy applied to the references of an exercise being deleted
+type Policy int
+
+const (
+	//Restrict refuses to delete an exercise while something references it
+	Restrict Policy = iota
+	//Cascade deletes what references the exercise along with it
+	Cascade
+	//Nullify removes the references to the exercise, keeping what held them
+	Nullify
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Restrict:
+		return "restrict"
+	case Cascade:
+		return "cascade"
+	case Nullify:
+		return "nullify"
+	}
+	return fmt.Sprintf("policy(%d)", int(p))
+}
+
+//Referrer is a subsystem whose entities reference exercises. The registry calls it with the ctx
+//of the delete, in its unit of work when the storage has transactions
+type Referrer interface {
+	//Kind of the entities referencing exercises, ex: workout
+	Kind() string
+	//Referencing returns the ids of the entities referencing the exercise
+	Referencing(ctx context.Context, exerciseID string) ([]string, error)
+	//Delete deletes the entities referencing the exercise
+	Delete(ctx context.Context, exerciseID string) error
+	//Unlink removes the references to the exercise from the entities holding them
+	Unlink(ctx context.Context, exerciseID string) error
+	//Replace points the references to the exercise at another one, when merging duplicates
+	Replace(ctx context.Context, exerciseID, replacementID string) error
+}
+
+//Reference of an entity to an exercise
+type Reference struct {
+	Kind string
+	ID   string
+}
+
+func (r Reference) String() string {
+	return r.Kind + "/" + r.ID
+}
+
+//Registry of the referrers, safe for concurrent use. Register them before serving
+type Registry struct {
+	mu        sync.RWMutex
+	referrers []Referrer
+}
+
+//NewRegistry creates a registry without referrers
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+//Register adds a referrer, replacing the one of the same kind
+func (r *Registry) Register(ref Referrer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, old := range r.referrers {
+		if old.Kind() == ref.Kind() {
+			r.referrers[i] = ref
+			return
+		}
+	}
+	r.referrers = append(r.referrers, ref)
+	sort.Slice(r.referrers, func(i, j int) bool { return r.referrers[i].Kind() < r.referrers[j].Kind() })
+}
+
+func (r *Registry) all() []Referrer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Referrer(nil), r.referrers...)
+}
+
+//Find returns the references to the exercise, by kind then id
+func (r *Registry) Find(ctx context.Context, exerciseID string) ([]Reference, error) {
+	var l []Reference
+	for _, ref := range r.all() {
+		ids, err := ref.Referencing(ctx, exerciseID)
+		if err != nil {
+			return nil, fmt.Errorf("could not find the %v referencing exercise %v. Error was %v", ref.Kind(), exerciseID, err)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			l = append(l, Reference{Kind: ref.Kind(), ID: id})
+		}
+	}
+	return l, nil
+}
+
+//Release applies p to the references to the exercise before deleting it. With Restrict it returns
+//a ReferencedError when there are any
+func (r *Registry) Release(ctx context.Context, exerciseID string, p Policy) error {
+	switch p {
+	case Restrict:
+		l, err := r.Find(ctx, exerciseID)
+		if err != nil {
+			return err
+		}
+		if len(l) > 0 {
+			return &ReferencedError{ExerciseID: exerciseID, References: l}
+		}
+		return nil
+	case Cascade, Nullify:
+		for _, ref := range r.all() {
+			release := ref.Delete
+			if p == Nullify {
+				release = ref.Unlink
+			}
+			if err := release(ctx, exerciseID); err != nil {
+				return fmt.Errorf("could not %v the %v referencing exercise %v. Error was %v", p, ref.Kind(), exerciseID, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown %v", p)
+}
+
+//Rewrite points every reference to the exercise at its replacement, before deleting the exercise
+func (r *Registry) Rewrite(ctx context.Context, exerciseID, replacementID string) error {
+	for _, ref := range r.all() {
+		if err := ref.Replace(ctx, exerciseID, replacementID); err != nil {
+			return fmt.Errorf("could not point the %v referencing exercise %v at %v. Error was %v", ref.Kind(), exerciseID, replacementID, err)
+		}
+	}
+	return nil
+}
+
+//maxListed bounds the references listed in the details of a ReferencedError
+const maxListed = 50
+
+//ReferencedError is returned when deleting an exercise still referenced. It converts into a
+//FailedPrecondition status listing the references as violations
+type ReferencedError struct {
+	ExerciseID string
+	References []Reference
+}
+
+func (e *ReferencedError) Error() string {
+	l := make([]string, 0, len(e.References))
+	for _, r := range e.References {
+		l = append(l, r.String())
+	}
+	return fmt.Sprintf("exercise %v is referenced by %v", e.ExerciseID, strings.Join(l, ", "))
+}
+
+//GRPCStatus lets grpc send the error as FailedPrecondition
+func (e *ReferencedError) GRPCStatus() *status.Status {
+	msg := fmt.Sprintf("exercise %v is referenced by %d entities, delete it with the cascade or nullify policy", e.ExerciseID, len(e.References))
+	st := status.New(codes.FailedPrecondition, msg)
+	var violations []*errdetails.PreconditionFailure_Violation
+	for i, r := range e.References {
+		if i == maxListed {
+			break
+		}
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "REFERENCED",
+			Subject:     r.String(),
+			Description: fmt.Sprintf("%s %s references the exercise", r.Kind, r.ID),
+		})
+	}
+	if detailed, err := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations}); err == nil {
+		return detailed
+	}
+	return st
+}
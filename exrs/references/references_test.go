@@ -0,0 +1,162 @@
+// +build unit
+
+package references
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//fakeReferrer holds references from its entities to exercises
+type fakeReferrer struct {
+	kind string
+	//refs maps entity ids to the exercises they reference
+	refs map[string][]string
+	err  error
+}
+
+func (f *fakeReferrer) Kind() string { return f.kind }
+
+func (f *fakeReferrer) Referencing(_ context.Context, exerciseID string) ([]string, error) {
+	var ids []string
+	for id, exercises := range f.refs {
+		for _, e := range exercises {
+			if e == exerciseID {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, f.err
+}
+
+func (f *fakeReferrer) Delete(ctx context.Context, exerciseID string) error {
+	ids, _ := f.Referencing(ctx, exerciseID)
+	for _, id := range ids {
+		delete(f.refs, id)
+	}
+	return f.err
+}
+
+func (f *fakeReferrer) Unlink(_ context.Context, exerciseID string) error {
+	for id, exercises := range f.refs {
+		var kept []string
+		for _, e := range exercises {
+			if e != exerciseID {
+				kept = append(kept, e)
+			}
+		}
+		f.refs[id] = kept
+	}
+	return f.err
+}
+
+func (f *fakeReferrer) Replace(_ context.Context, exerciseID, replacementID string) error {
+	for _, exercises := range f.refs {
+		for i, e := range exercises {
+			if e == exerciseID {
+				exercises[i] = replacementID
+			}
+		}
+	}
+	return f.err
+}
+
+func newRegistry() (*Registry, *fakeReferrer, *fakeReferrer) {
+	workouts := &fakeReferrer{kind: "workout", refs: map[string][]string{"w2": {"squat", "lunge"}, "w1": {"squat"}, "w3": {"lunge"}}}
+	programs := &fakeReferrer{kind: "program", refs: map[string][]string{"p1": {"squat"}}}
+	r := NewRegistry()
+	r.Register(workouts)
+	r.Register(programs)
+	return r, workouts, programs
+}
+
+func TestFind(t *testing.T) {
+	r, _, _ := newRegistry()
+	l, err := r.Find(context.Background(), "squat")
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{{"program", "p1"}, {"workout", "w1"}, {"workout", "w2"}}, l)
+	l, err = r.Find(context.Background(), "deadlift")
+	require.NoError(t, err)
+	assert.Empty(t, l)
+}
+
+func TestRelease(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   Policy
+		err      bool
+		workouts map[string][]string
+	}{
+		{"restrict", Restrict, true, map[string][]string{"w1": {"squat"}, "w2": {"squat", "lunge"}, "w3": {"lunge"}}},
+		{"cascade", Cascade, false, map[string][]string{"w3": {"lunge"}}},
+		{"nullify", Nullify, false, map[string][]string{"w1": nil, "w2": {"lunge"}, "w3": {"lunge"}}},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r, workouts, programs := newRegistry()
+			err := r.Release(context.Background(), "squat", tc.policy)
+			assert.Equal(t, tc.err, err != nil, "%v", err)
+			assert.Equal(t, tc.workouts, workouts.refs)
+			l, _ := programs.Referencing(context.Background(), "squat")
+			assert.Equal(t, tc.err, len(l) > 0, "every referrer is released")
+		})
+	}
+}
+
+func TestReleaseUnreferenced(t *testing.T) {
+	r, _, _ := newRegistry()
+	assert.NoError(t, r.Release(context.Background(), "deadlift", Restrict))
+	assert.NoError(t, NewRegistry().Release(context.Background(), "squat", Restrict))
+}
+
+func TestReleaseFailure(t *testing.T) {
+	r, workouts, _ := newRegistry()
+	workouts.err = errors.New("workouts are down")
+	for _, p := range []Policy{Restrict, Cascade, Nullify} {
+		err := r.Release(context.Background(), "squat", p)
+		assert.Error(t, err, "%v", p)
+		var referenced *ReferencedError
+		assert.False(t, errors.As(err, &referenced), "%v fails with the error of the referrer", p)
+	}
+}
+
+func TestReferencedErrorStatus(t *testing.T) {
+	r, _, _ := newRegistry()
+	err := r.Release(context.Background(), "squat", Restrict)
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	var subjects []string
+	for _, v := range st.Details()[0].(*errdetails.PreconditionFailure).Violations {
+		subjects = append(subjects, v.Subject)
+		assert.Equal(t, "REFERENCED", v.Type)
+	}
+	assert.Equal(t, []string{"program/p1", "workout/w1", "workout/w2"}, subjects)
+}
+
+func TestRewrite(t *testing.T) {
+	r, workouts, programs := newRegistry()
+	require.NoError(t, r.Rewrite(context.Background(), "squat", "back-squat"))
+	assert.Equal(t, map[string][]string{"w1": {"back-squat"}, "w2": {"back-squat", "lunge"}, "w3": {"lunge"}}, workouts.refs)
+	assert.Equal(t, map[string][]string{"p1": {"back-squat"}}, programs.refs)
+
+	workouts.err = errors.New("workouts are down")
+	assert.Error(t, r.Rewrite(context.Background(), "lunge", "split-squat"))
+}
+
+func TestRegisterReplaces(t *testing.T) {
+	r, _, _ := newRegistry()
+	r.Register(&fakeReferrer{kind: "workout", refs: map[string][]string{}})
+	l, err := r.Find(context.Background(), "squat")
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{{"program", "p1"}}, l)
+}
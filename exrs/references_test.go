@@ -0,0 +1,133 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/references"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//workouts is a referrer whose workouts all reference one exercise
+type workouts struct {
+	exerciseID string
+	ids        []string
+	released   references.Policy
+	err        error
+}
+
+func (w *workouts) Kind() string { return "workout" }
+
+func (w *workouts) Referencing(_ context.Context, exerciseID string) ([]string, error) {
+	if exerciseID != w.exerciseID {
+		return nil, nil
+	}
+	return w.ids, nil
+}
+
+func (w *workouts) Delete(context.Context, string) error {
+	w.released = references.Cascade
+	return w.err
+}
+
+func (w *workouts) Unlink(context.Context, string) error {
+	w.released = references.Nullify
+	return w.err
+}
+
+func (w *workouts) Replace(_ context.Context, exerciseID, replacementID string) error {
+	if exerciseID == w.exerciseID {
+		w.exerciseID = replacementID
+	}
+	return w.err
+}
+
+func TestDeleteReferenced(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   pbexrs.DeletePolicy
+		err      error
+		code     codes.Code
+		released references.Policy
+		deleted  bool
+	}{
+		{"restricted by default", pbexrs.DeletePolicy_DELETE_POLICY_UNSPECIFIED, nil, codes.FailedPrecondition, references.Restrict, false},
+		{"restrict", pbexrs.DeletePolicy_RESTRICT, nil, codes.FailedPrecondition, references.Restrict, false},
+		{"cascade", pbexrs.DeletePolicy_CASCADE, nil, codes.OK, references.Cascade, true},
+		{"nullify", pbexrs.DeletePolicy_NULLIFY, nil, codes.OK, references.Nullify, true},
+		{"failing cascade is rolled back", pbexrs.DeletePolicy_CASCADE, errors.New("workouts are down"), codes.Unknown, references.Cascade, false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			repo := openBolt(t)
+			e, err := repo.Create(ctx, &storage.Exercise{Name: "squat"})
+			require.NoError(t, err)
+			w := &workouts{exerciseID: e.Id, ids: []string{"leg-day"}, err: tc.err}
+			registry := references.NewRegistry()
+			registry.Register(w)
+			api, _ := Server(repo, repo, WithRevisions(repo), WithTransactions(repo), WithReferences(registry))
+
+			_, err = api.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: e.Id, Policy: tc.policy})
+			assert.Equal(t, tc.code, status.Code(err), "%v", err)
+			assert.Equal(t, tc.released, w.released)
+			_, err = repo.Read(ctx, e.Id)
+			assert.Equal(t, tc.deleted, err != nil, "deleted %v", tc.deleted)
+			revisions, err := repo.ListRevisions(ctx, e.Id)
+			require.NoError(t, err)
+			assert.Equal(t, tc.deleted, len(revisions) == 1, "only a delete records a revision")
+		})
+	}
+}
+
+func TestDeleteUnreferenced(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	e, err := repo.Create(ctx, &storage.Exercise{Name: "squat"})
+	require.NoError(t, err)
+	registry := references.NewRegistry()
+	registry.Register(&workouts{exerciseID: "5f1d7f3b2c8e4a0001a1b2c3", ids: []string{"leg-day"}})
+	api, _ := Server(repo, repo, WithReferences(registry))
+
+	_, err = api.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: e.Id, Policy: pbexrs.DeletePolicy_RESTRICT})
+	require.NoError(t, err)
+	_, err = repo.Read(ctx, e.Id)
+	assert.Error(t, err)
+}
+
+func TestDeleteMissing(t *testing.T) {
+	const missing = "5f1d7f3b2c8e4a0001a1b2c3"
+	testCases := []struct {
+		name   string
+		policy pbexrs.DeletePolicy
+	}{
+		{"cascade", pbexrs.DeletePolicy_CASCADE},
+		{"nullify", pbexrs.DeletePolicy_NULLIFY},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			repo := openBolt(t)
+			w := &workouts{exerciseID: missing, ids: []string{"leg-day"}}
+			registry := references.NewRegistry()
+			registry.Register(w)
+			api, _ := Server(repo, repo, WithRevisions(repo), WithTransactions(repo), WithReferences(registry))
+
+			_, err := api.DeleteExercise(context.Background(), &pbexrs.DeleteRequest{Id: missing, Policy: tc.policy})
+			assert.Equal(t, codes.NotFound, status.Code(err), "%v", err)
+			assert.Equal(t, references.Restrict, w.released, "nothing released")
+			assert.Equal(t, missing, w.exerciseID)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package exrs
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//WithRevisions records a revision of every change of the exercises in r
+func WithRevisions(r storage.RevisionStorage) Option {
+	return func(s *API) {
+		s.revisions = r
+	}
+}
+
+//WithTransactions runs the writes of each call, like an exercise and its revision, in a unit of
+//work of t. Without it they run one after the other
+func WithTransactions(t storage.Transactor) Option {
+	return func(s *API) {
+		s.tx = t
+	}
+}
+
+//ListExerciseRevisions returns the history of an exercise, oldest first
+func (s *API) ListExerciseRevisions(ctx context.Context, req *pbexrs.ListExerciseRevisionsRequest) (*pbexrs.ListExerciseRevisionsResponse, error) {
+	if s.revisions == nil {
+		return &pbexrs.ListExerciseRevisionsResponse{}, status.Error(codes.Unimplemented, "revisions are not recorded")
+	}
+	l, err := s.revisions.ListRevisions(ctx, req.GetId())
+	if err != nil {
+		ctxzap.Extract(ctx).Sugar().Warnf("could not list revisions of exercise %v. Error was %v", req.GetId(), err)
+		return &pbexrs.ListExerciseRevisionsResponse{}, err
+	}
+	revisions := make([]*pbexrs.ExerciseRevision, 0, len(l))
+	for _, r := range l {
+		revisions = append(revisions, UnmarshallRevision(r))
+	}
+	return &pbexrs.ListExerciseRevisionsResponse{Revisions: revisions}, nil
+}
+
+//record adds a revision of the exercise id, e is the exercise after the change. Merges give the
+//exercises merged with it
+func (s *API) record(ctx context.Context, action, id string, e *storage.Exercise, mergedWith ...string) error {
+	if s.revisions == nil {
+		return nil
+	}
+	return s.revisions.AddRevision(ctx, &storage.Revision{
+		ExerciseID: id,
+		Action:     action,
+		Subject:    auth.Subject(ctx),
+		Time:       now(),
+		Exercise:   e,
+		MergedWith: mergedWith,
+	})
+}
+
+//UnmarshallRevision converts a stored revision into a transport layer revision
+func UnmarshallRevision(r *storage.Revision) *pbexrs.ExerciseRevision {
+	if r == nil {
+		return nil
+	}
+	return &pbexrs.ExerciseRevision{
+		Id:         r.Id,
+		ExerciseId: r.ExerciseID,
+		Action:     pbexrs.RevisionAction(enumFromStorage(r.Action, pbexrs.RevisionAction_value)),
+		Subject:    r.Subject,
+		Time:       toTimestamp(r.Time),
+		Exercise:   UnmarshallExercise(r.Exercise),
+		MergedWith: r.MergedWith,
+	}
+}
@@ -0,0 +1,90 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/storage/boltdb"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//failingRevisions can not record revisions
+type failingRevisions struct {
+	storage.RevisionStorage
+}
+
+func (failingRevisions) AddRevision(context.Context, *storage.Revision) error {
+	return errors.New("revisions are down")
+}
+
+func openBolt(t *testing.T) *boltdb.Storage {
+	s, err := boltdb.New(filepath.Join(t.TempDir(), "exrs.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	api, _ := Server(repo, repo, WithRevisions(repo), WithTransactions(repo))
+
+	created, err := api.CreateExercise(ctx, &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{Name: "squat", Kind: pbexrs.Kind_ANAEROBIC}})
+	require.NoError(t, err)
+	_, err = api.UpdateExercise(ctx, &pbexrs.UpdateRequest{Id: created.Id, Exercise: &pbexrs.Exercise{Name: "front squat"}})
+	require.NoError(t, err)
+	_, err = api.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: created.Id})
+	require.NoError(t, err)
+	_, err = api.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: created.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err), "%v", err)
+
+	resp, err := api.ListExerciseRevisions(ctx, &pbexrs.ListExerciseRevisionsRequest{Id: created.Id})
+	require.NoError(t, err)
+	var actions []pbexrs.RevisionAction
+	for _, r := range resp.Revisions {
+		actions = append(actions, r.Action)
+		assert.Equal(t, created.Id, r.ExerciseId)
+		assert.NotNil(t, r.Time)
+	}
+	assert.Equal(t, []pbexrs.RevisionAction{pbexrs.RevisionAction_CREATE, pbexrs.RevisionAction_UPDATE, pbexrs.RevisionAction_DELETE}, actions,
+		"deleting a missing exercise changes nothing")
+	assert.Equal(t, "squat", resp.Revisions[0].Exercise.Name)
+	assert.Equal(t, "front squat", resp.Revisions[1].Exercise.Name)
+	assert.Nil(t, resp.Revisions[2].Exercise)
+}
+
+func TestRevisionsRollback(t *testing.T) {
+	ctx := context.Background()
+	repo := openBolt(t)
+	kept, err := repo.Create(ctx, &storage.Exercise{Name: "lunge"})
+	require.NoError(t, err)
+	api, _ := Server(repo, repo, WithRevisions(failingRevisions{repo}), WithTransactions(repo))
+
+	_, err = api.CreateExercise(ctx, &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{Name: "squat", Kind: pbexrs.Kind_ANAEROBIC}})
+	assert.Error(t, err)
+	_, err = api.UpdateExercise(ctx, &pbexrs.UpdateRequest{Id: kept.Id, Exercise: &pbexrs.Exercise{Name: "split squat"}})
+	assert.Error(t, err)
+	_, err = api.DeleteExercise(ctx, &pbexrs.DeleteRequest{Id: kept.Id})
+	assert.Error(t, err)
+
+	l, err := repo.List(ctx, storage.Filter{})
+	require.NoError(t, err)
+	require.Len(t, l, 1, "the exercise created without revision is rolled back")
+	assert.Equal(t, "lunge", l[0].Name, "so are the update and the delete")
+}
+
+func TestRevisionsNotRecorded(t *testing.T) {
+	repo := openBolt(t)
+	api, _ := Server(repo, repo)
+	_, err := api.ListExerciseRevisions(context.Background(), &pbexrs.ListExerciseRevisionsRequest{Id: "5f1d7f3b2c8e4a0001a1b2c3"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,89 @@
+package exrs
+
+import (
+	v "github.com/maxvw8/exercise_lib/exrs/validation"
+)
+
+//Rules validated on every request of the ExerciseService before reaching the API
+var Rules = v.Rules{
+	"pbexrs.Exercise": {
+		"name":            {v.MaxLen(120)},
+		"kind":            {v.Defined},
+		"categories":      {v.NotBlank},
+		"muscles":         {v.NotBlank},
+		"muscle_groups":   {v.NotBlank},
+		"images":          {v.URL},
+		"description":     {v.MaxLen(4000)},
+		"instructions":    {v.NotBlank},
+		"tips":            {v.NotBlank},
+		"common_mistakes": {v.NotBlank},
+		"difficulty":      {v.Defined},
+		"mechanics":       {v.Defined},
+		"force":           {v.Defined},
+		"image_ids":       {v.ObjectID},
+	},
+	"pbexrs.Video": {
+		"":         {videoRule},
+		"provider": {v.Required, v.Defined},
+		"url":      {v.URL},
+		"asset_id": {v.ObjectID},
+		"angle":    {v.Defined},
+	},
+	"pbexrs.CaptionTrack": {
+		"language": {v.Required, v.MaxLen(35)},
+		"url":      {v.Required, v.URL},
+	},
+	"pbexrs.GetExerciseRequest": {
+		"id":          {v.Required, v.ObjectID},
+		"video_angle": {v.Defined},
+	},
+	"pbexrs.CreateExerciseRequest": {
+		"exercise":      {v.Required},
+		"exercise.id":   {v.ObjectID},
+		"exercise.name": {v.Required},
+		"exercise.kind": {v.Required},
+	},
+	"pbexrs.UpdateRequest": {
+		"id":       {v.Required, v.ObjectID},
+		"exercise": {v.Required},
+	},
+	"pbexrs.DeleteRequest": {
+		"id":     {v.Required, v.ObjectID},
+		"policy": {v.Defined},
+	},
+	"pbexrs.ListExercisesRequest": {
+		"page_size":   {v.Range(0, 1000)},
+		"difficulty":  {v.Defined},
+		"video_angle": {v.Defined},
+	},
+	"pbexrs.ListExerciseRevisionsRequest": {
+		"id": {v.Required, v.ObjectID},
+	},
+	"pbexrs.FindDuplicateExercisesRequest": {
+		"min_score": {v.Range(0, 100)},
+	},
+	"pbexrs.MergeExercisesRequest": {
+		"":              {mergeRule},
+		"survivor_id":   {v.Required, v.ObjectID},
+		"duplicate_ids": {v.Required, v.ObjectID},
+	},
+	"pbexrs.RecommendExercisesRequest": {
+		"muscle_groups": {v.Required, v.NotBlank},
+		"equipment":     {v.NotBlank},
+		"difficulty":    {v.Defined},
+		"exclude_ids":   {v.ObjectID},
+		"limit":         {v.Range(0, 50)},
+	},
+	"pbexrs.ListTaxonomyTermsRequest": {
+		"type": {v.Defined},
+	},
+	"pbexrs.CreateTaxonomyTermRequest": {
+		"term":      {v.Required},
+		"term.type": {v.Required, v.Defined},
+		"term.name": {v.Required, v.MaxLen(60)},
+	},
+	"pbexrs.DeleteTaxonomyTermRequest": {
+		"type": {v.Required, v.Defined},
+		"name": {v.Required},
+	},
+}
@@ -0,0 +1,174 @@
+// +build unit
+
+package exrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestRules(t *testing.T) {
+	validID := "5f1b0c7e8e3b8a0a4c6d1e2f"
+	testCases := []struct {
+		Name       string
+		Input      protoreflect.ProtoMessage
+		Violations []string
+	}{
+		{
+			Name:       "create without exercise",
+			Input:      &pbexrs.CreateExerciseRequest{},
+			Violations: []string{"exercise", "exercise.kind", "exercise.name"},
+		},
+		{
+			Name: "create valid exercise",
+			Input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Name:   "push up",
+				Kind:   pbexrs.Kind_ANAEROBIC,
+				Images: []string{"https://example.com/push-up.jpg"},
+			}},
+		},
+		{
+			Name: "create invalid exercise",
+			Input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Name:       "push up",
+				Kind:       pbexrs.Kind(42),
+				Categories: []string{"chest", ""},
+				Images:     []string{"https://example.com/push-up.jpg", "push-up.jpg"},
+				Videos: []*pbexrs.Video{{
+					Provider:    pbexrs.VideoProvider_YOUTUBE,
+					Url:         "https://vimeo.com/76979871",
+					StartOffset: ptypes.DurationProto(10 * time.Second),
+					EndOffset:   ptypes.DurationProto(5 * time.Second),
+				}},
+				Difficulty: pbexrs.Difficulty(7),
+			}},
+			Violations: []string{"exercise.categories[1]", "exercise.difficulty", "exercise.images[1]", "exercise.kind",
+				"exercise.videos[0].url", "exercise.videos[0].end_offset"},
+		},
+		{
+			Name: "create exercise with videos",
+			Input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Name: "push up",
+				Kind: pbexrs.Kind_ANAEROBIC,
+				Videos: []*pbexrs.Video{
+					{
+						Provider:    pbexrs.VideoProvider_YOUTUBE,
+						Url:         "https://www.youtube.com/watch?v=IODxDxX7oi4",
+						Duration:    ptypes.DurationProto(5 * time.Minute),
+						StartOffset: ptypes.DurationProto(30 * time.Second),
+						EndOffset:   ptypes.DurationProto(90 * time.Second),
+						Angle:       pbexrs.CameraAngle_SIDE,
+						Captions:    []*pbexrs.CaptionTrack{{Language: "en", Url: "https://example.com/push-up.vtt"}},
+					},
+					{
+						Provider: pbexrs.VideoProvider_HOSTED,
+						AssetId:  validID,
+					},
+				},
+			}},
+		},
+		{
+			Name: "create exercise with invalid videos",
+			Input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Name: "push up",
+				Kind: pbexrs.Kind_ANAEROBIC,
+				Videos: []*pbexrs.Video{
+					{
+						Provider:    pbexrs.VideoProvider_HOSTED,
+						Duration:    ptypes.DurationProto(time.Minute),
+						StartOffset: ptypes.DurationProto(2 * time.Minute),
+						Captions:    []*pbexrs.CaptionTrack{{Url: "captions.vtt"}},
+					},
+					{},
+				},
+			}},
+			Violations: []string{"exercise.videos[0].asset_id", "exercise.videos[0].start_offset",
+				"exercise.videos[0].captions[0].language", "exercise.videos[0].captions[0].url", "exercise.videos[1].provider"},
+		},
+		{
+			Name: "create exercise with invalid video duration",
+			Input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Name: "push up",
+				Kind: pbexrs.Kind_ANAEROBIC,
+				Videos: []*pbexrs.Video{{
+					Provider:    pbexrs.VideoProvider_HOSTED,
+					AssetId:     validID,
+					Duration:    &duration.Duration{Seconds: 60, Nanos: -1},
+					StartOffset: ptypes.DurationProto(30 * time.Second),
+					EndOffset:   &duration.Duration{Seconds: -1},
+				}},
+			}},
+			Violations: []string{"exercise.videos[0].duration", "exercise.videos[0].end_offset"},
+		},
+		{
+			Name:       "get malformed id",
+			Input:      &pbexrs.GetExerciseRequest{Id: "1"},
+			Violations: []string{"id"},
+		},
+		{
+			Name:  "get valid id",
+			Input: &pbexrs.GetExerciseRequest{Id: validID},
+		},
+		{
+			Name:       "update without id nor exercise",
+			Input:      &pbexrs.UpdateRequest{},
+			Violations: []string{"exercise", "id"},
+		},
+		{
+			Name:  "partial update",
+			Input: &pbexrs.UpdateRequest{Id: validID, Exercise: &pbexrs.Exercise{Tips: []string{"keep your back straight"}}},
+		},
+		{
+			Name:       "negative page size",
+			Input:      &pbexrs.ListExercisesRequest{PageSize: -1},
+			Violations: []string{"page_size"},
+		},
+		{
+			Name:       "term without type",
+			Input:      &pbexrs.CreateTaxonomyTermRequest{Term: &pbexrs.TaxonomyTerm{Name: "chest"}},
+			Violations: []string{"term.type"},
+		},
+		{
+			Name:       "merge without duplicates",
+			Input:      &pbexrs.MergeExercisesRequest{SurvivorId: validID},
+			Violations: []string{"duplicate_ids"},
+		},
+		{
+			Name:       "merge into itself",
+			Input:      &pbexrs.MergeExercisesRequest{SurvivorId: validID, DuplicateIds: []string{"5f1b0c7e8e3b8a0a4c6d1e30", validID, "5f1b0c7e8e3b8a0a4c6d1e30"}},
+			Violations: []string{"duplicate_ids[1]", "duplicate_ids[2]"},
+		},
+		{
+			Name:       "recommend without muscle groups",
+			Input:      &pbexrs.RecommendExercisesRequest{Equipment: []string{""}, Limit: 51},
+			Violations: []string{"equipment[0]", "limit", "muscle_groups"},
+		},
+		{
+			Name:       "min score over 100",
+			Input:      &pbexrs.FindDuplicateExercisesRequest{MinScore: 101},
+			Violations: []string{"min_score"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			var fields []string
+			for _, v := range Rules.Validate(tc.Input) {
+				fields = append(fields, v.Field)
+			}
+			assert.Equal(t, tc.Violations, fields)
+		})
+	}
+}
+
+func TestRulesCheck(t *testing.T) {
+	assert.NoError(t, Rules.Check())
+}
@@ -0,0 +1,343 @@
+//Package boltdb stores the exercises in a single file, for deployments without a database server
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	exercisesBucket = []byte("exercises")
+	taxonomyBucket  = []byte("taxonomy")
+	//indexes map a value and an exercise id to nothing, their keys sort the exercises by value then id
+	nameIndex        = []byte("index_name")
+	kindIndex        = []byte("index_kind")
+	muscleGroupIndex = []byte("index_muscle_group")
+	difficultyIndex  = []byte("index_difficulty")
+	//updateTimeIndex keys are the update time in milliseconds, the precision mongo stores
+	updateTimeIndex = []byte("index_update_time")
+	//revisionsBucket keys are the exercise id then a sequence, sorting the revisions of an exercise oldest first
+	revisionsBucket = []byte("revisions")
+
+	buckets = [][]byte{exercisesBucket, taxonomyBucket, nameIndex, kindIndex, muscleGroupIndex, difficultyIndex, updateTimeIndex, revisionsBucket}
+)
+
+//Storage keeps exercises and the taxonomy in a bolt file. Every write is a transaction synced to
+//disk before it returns, a crash loses nothing acknowledged
+type Storage struct {
+	db *bolt.DB
+}
+
+//New opens the bolt file at path, creating it when missing. Only one process can open it at a time
+func New(path string) (*Storage, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v. Error was %v", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create buckets in %v. Error was %v", path, err)
+	}
+	return &Storage{db}, nil
+}
+
+//Create a new Exercise, with a new id
+func (lib *Storage) Create(ctx context.Context, e *storage.Exercise) (*storage.Exercise, error) {
+	e.Id = primitive.NewObjectID().Hex()
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		return put(tx, e)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new exercise %v. Error was %v", e, err)
+	}
+	return e, nil
+}
+
+//Read an exercise by id
+func (lib *Storage) Read(ctx context.Context, id string) (*storage.Exercise, error) {
+	var e *storage.Exercise
+	err := lib.view(ctx, func(tx *bolt.Tx) (err error) {
+		e, err = get(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find record by id %v. Error was %v", id, err)
+	}
+	return e, nil
+}
+
+//Update sets the non empty fields of e on the exercise, like a mongo $set
+func (lib *Storage) Update(ctx context.Context, id string, e *storage.Exercise) (*storage.Exercise, error) {
+	var updated *storage.Exercise
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		old, err := get(tx, id)
+		if err != nil {
+			return err
+		}
+		if updated, err = storage.Merge(old, e); err != nil {
+			return err
+		}
+		updated.Id = id
+		unindex(tx, old)
+		return put(tx, updated)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not update record %v. Error was %v", e, err)
+	}
+	return updated, nil
+}
+
+//Delete an Exercise, returns false if it did not exist
+func (lib *Storage) Delete(ctx context.Context, id string) (bool, error) {
+	found := false
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		old, err := get(tx, id)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+		unindex(tx, old)
+		return tx.Bucket(exercisesBucket).Delete([]byte(id))
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not delete record by id %v. Error was %v", id, err)
+	}
+	return found, nil
+}
+
+//List obtains the exercises matching the filter, sorted by id or by update time when
+//UpdatedAfter is set, as the mongo storage does
+func (lib *Storage) List(ctx context.Context, f storage.Filter) ([]*storage.Exercise, error) {
+	exes := []*storage.Exercise{}
+	skip := f.Offset
+	err := lib.view(ctx, func(tx *bolt.Tx) error {
+		//collect is false once the page is full
+		collect := func(id []byte) (bool, error) {
+			e, err := get(tx, string(id))
+			if err != nil {
+				return false, err
+			}
+			if (f.Difficulty != "" && e.Difficulty != f.Difficulty) || (!f.UpdatedAfter.IsZero() && !millis(e.UpdateTime).After(millis(f.UpdatedAfter))) {
+				return true, nil
+			}
+			if skip > 0 {
+				skip--
+				return true, nil
+			}
+			exes = append(exes, e)
+			return f.Limit <= 0 || len(exes) < f.Limit, nil
+		}
+		switch {
+		case !f.UpdatedAfter.IsZero():
+			c := tx.Bucket(updateTimeIndex).Cursor()
+			for k, _ := c.Seek(timeKey(millis(f.UpdatedAfter).Add(time.Millisecond))); k != nil; k, _ = c.Next() {
+				if more, err := collect(k[8:]); err != nil || !more {
+					return err
+				}
+			}
+		case f.Difficulty != "":
+			return scan(tx, difficultyIndex, f.Difficulty, collect)
+		default:
+			c := tx.Bucket(exercisesBucket).Cursor()
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				if more, err := collect(k); err != nil || !more {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find records. %v", err)
+	}
+	return exes, nil
+}
+
+//GetByName returns the exercises named name
+func (lib *Storage) GetByName(ctx context.Context, name string) ([]*storage.Exercise, error) {
+	return lib.lookup(ctx, nameIndex, name)
+}
+
+//GetByKind returns the exercises of a kind
+func (lib *Storage) GetByKind(ctx context.Context, kind string) ([]*storage.Exercise, error) {
+	return lib.lookup(ctx, kindIndex, kind)
+}
+
+//GetByMuscleGroup returns the exercises working a muscle group
+func (lib *Storage) GetByMuscleGroup(ctx context.Context, mg string) ([]*storage.Exercise, error) {
+	return lib.lookup(ctx, muscleGroupIndex, mg)
+}
+
+func (lib *Storage) lookup(ctx context.Context, index []byte, value string) ([]*storage.Exercise, error) {
+	exes := []*storage.Exercise{}
+	err := lib.view(ctx, func(tx *bolt.Tx) error {
+		return scan(tx, index, value, func(id []byte) (bool, error) {
+			e, err := get(tx, string(id))
+			if err != nil {
+				return false, err
+			}
+			exes = append(exes, e)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find records by %s %v. Error was %v", index, value, err)
+	}
+	return exes, nil
+}
+
+//InTransaction runs fn in a single bolt write transaction. Other writers wait for it to end, keep
+//units of work short
+func (lib *Storage) InTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if storage.Transaction(ctx) != nil {
+		return fn(ctx)
+	}
+	var unit context.Context
+	err := lib.db.Update(func(tx *bolt.Tx) error {
+		unit = storage.WithTransaction(ctx, tx)
+		return fn(unit)
+	})
+	if err != nil {
+		return err
+	}
+	storage.Committed(unit)
+	return nil
+}
+
+//update runs fn in the transaction of the unit of work of ctx, or in a transaction of its own
+func (lib *Storage) update(ctx context.Context, fn func(*bolt.Tx) error) error {
+	if tx, ok := storage.Transaction(ctx).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return lib.db.Update(fn)
+}
+
+//view reads within the unit of work of ctx, seeing what it wrote, or in a read transaction
+func (lib *Storage) view(ctx context.Context, fn func(*bolt.Tx) error) error {
+	if tx, ok := storage.Transaction(ctx).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return lib.db.View(fn)
+}
+
+//Ping checks the file is still open
+func (lib *Storage) Ping(ctx context.Context) error {
+	if err := lib.db.View(func(*bolt.Tx) error { return nil }); err != nil {
+		return fmt.Errorf("failed to ping database. Error was %v", err)
+	}
+	return nil
+}
+
+//Close the file
+func (lib *Storage) Close() error {
+	if err := lib.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database. Error %v", err)
+	}
+	return nil
+}
+
+var errNotFound = errors.New("no exercise with this id")
+
+func get(tx *bolt.Tx, id string) (*storage.Exercise, error) {
+	b := tx.Bucket(exercisesBucket).Get([]byte(id))
+	if b == nil {
+		return nil, errNotFound
+	}
+	var e *storage.Exercise
+	if err := bson.Unmarshal(b, &e); err != nil {
+		return nil, fmt.Errorf("could not parse exercise %v. Error was %v", id, err)
+	}
+	return e, nil
+}
+
+//put stores e and indexes it
+func put(tx *bolt.Tx, e *storage.Exercise) error {
+	b, err := bson.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(exercisesBucket).Put([]byte(e.Id), b); err != nil {
+		return err
+	}
+	for _, k := range indexKeys(e) {
+		if err := tx.Bucket(k.index).Put(k.key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//unindex removes the index entries of e, before it is replaced or deleted
+func unindex(tx *bolt.Tx, e *storage.Exercise) {
+	for _, k := range indexKeys(e) {
+		tx.Bucket(k.index).Delete(k.key)
+	}
+}
+
+type indexKey struct {
+	index []byte
+	key   []byte
+}
+
+func indexKeys(e *storage.Exercise) []indexKey {
+	id := []byte(e.Id)
+	keys := []indexKey{
+		{nameIndex, valueKey(e.Name, id)},
+		{kindIndex, valueKey(e.Kind, id)},
+		{difficultyIndex, valueKey(e.Difficulty, id)},
+		{updateTimeIndex, append(timeKey(millis(e.UpdateTime)), id...)},
+	}
+	for _, mg := range e.MuscleGroups {
+		keys = append(keys, indexKey{muscleGroupIndex, valueKey(mg, id)})
+	}
+	return keys
+}
+
+//valueKey sorts by value then id, values can not hold the 0 separator
+func valueKey(value string, id []byte) []byte {
+	return append(append([]byte(value), 0), id...)
+}
+
+//timeKey sorts times in 8 bytes, the sign bit flipped so times before 1970 come first
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()/int64(time.Millisecond))^(1<<63))
+	return k
+}
+
+//millis truncates t to the precision it is stored with
+func millis(t time.Time) time.Time {
+	return t.Truncate(time.Millisecond)
+}
+
+//scan calls fn with the ids index holds for value, in order, until it returns false
+func scan(tx *bolt.Tx, index []byte, value string, fn func(id []byte) (bool, error)) error {
+	prefix := valueKey(value, nil)
+	c := tx.Bucket(index).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		if more, err := fn(k[len(prefix):]); err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
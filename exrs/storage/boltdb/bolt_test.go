@@ -0,0 +1,125 @@
+// +build unit
+
+package boltdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/migration"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func open(t *testing.T) *Storage {
+	s, err := New(filepath.Join(t.TempDir(), "exrs.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Backend {
+		return open(t)
+	})
+}
+
+func TestIndexes(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	squat, _ := s.Create(ctx, &storage.Exercise{Name: "squat", Kind: "STRENGTH", MuscleGroups: []string{"quadriceps", "glutes"}})
+	s.Create(ctx, &storage.Exercise{Name: "run", Kind: "CARDIO", MuscleGroups: []string{"quadriceps"}})
+	_, err := s.Update(ctx, squat.Id, &storage.Exercise{Name: "front squat", MuscleGroups: []string{"quadriceps"}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		lookup func(context.Context, string) ([]*storage.Exercise, error)
+		value  string
+		want   []string
+	}{
+		{"name", s.GetByName, "front squat", []string{"front squat"}},
+		{"old name", s.GetByName, "squat", []string{}},
+		{"kind", s.GetByKind, "STRENGTH", []string{"front squat"}},
+		{"muscle group", s.GetByMuscleGroup, "quadriceps", []string{"front squat", "run"}},
+		{"old muscle group", s.GetByMuscleGroup, "glutes", []string{}},
+	}
+	for _, tc := range testCases {
+		l, err := tc.lookup(ctx, tc.value)
+		require.NoError(t, err)
+		got := []string{}
+		for _, e := range l {
+			got = append(got, e.Name)
+		}
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "exrs.db")
+	s, err := New(path)
+	require.NoError(t, err)
+	created, err := s.Create(ctx, &storage.Exercise{Name: "squat"})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	assert.Error(t, s.Ping(ctx))
+
+	s, err = New(path)
+	require.NoError(t, err)
+	defer s.Close()
+	read, err := s.Read(ctx, created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "squat", read.Name)
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	//an exercise stored before categories were renamed
+	legacy, err := bson.Marshal(bson.D{{Key: "_id", Value: "legacy"}, {Key: "name", Value: "squat"}, {Key: "category", Value: bson.A{"legs"}}})
+	require.NoError(t, err)
+	require.NoError(t, s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(exercisesBucket).Put([]byte("legacy"), legacy)
+	}))
+	r, err := migration.NewRunner(s.MigrationStore(), s.Migrations())
+	require.NoError(t, err)
+
+	ran, err := r.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, ran, 1)
+	e, err := s.Read(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legs"}, e.Categories)
+	ran, err = r.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ran)
+
+	_, err = r.To(ctx, 0)
+	require.NoError(t, err)
+	e, err = s.Read(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Empty(t, e.Categories)
+	status, err := r.Status(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, status[0].Applied)
+}
+
+func TestMigrationLock(t *testing.T) {
+	ctx := context.Background()
+	store := open(t).MigrationStore()
+	require.NoError(t, store.Lock(ctx, "a", time.Hour))
+	assert.Equal(t, migration.ErrLocked, store.Lock(ctx, "b", time.Hour))
+	require.NoError(t, store.Lock(ctx, "a", time.Hour), "the owner takes it again")
+	require.NoError(t, store.Unlock(ctx, "b"))
+	assert.Equal(t, migration.ErrLocked, store.Lock(ctx, "b", time.Hour), "only the owner unlocks")
+	require.NoError(t, store.Unlock(ctx, "a"))
+	require.NoError(t, store.Lock(ctx, "b", -time.Second))
+	require.NoError(t, store.Lock(ctx, "a", time.Hour), "expired locks are taken over")
+}
@@ -0,0 +1,172 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/migration"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	migrationsBucket    = []byte("migrations")
+	migrationLockBucket = []byte("migrations_lock")
+	lockKey             = []byte("lock")
+)
+
+//Migrations of the exercises stored by older versions
+func (lib *Storage) Migrations() []migration.Migration {
+	return []migration.Migration{
+		{Version: 1, Name: "category into categories",
+			Up: func(ctx context.Context) error {
+				return lib.renameField("category", "categories")
+			},
+			Down: func(ctx context.Context) error {
+				return lib.renameField("categories", "category")
+			}},
+	}
+}
+
+//renameField renames from into to on every exercise having it, in a single transaction
+func (lib *Storage) renameField(from, to string) error {
+	err := lib.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(exercisesBucket)
+		renamed := map[string][]byte{}
+		err := b.ForEach(func(k, v []byte) error {
+			var doc bson.D
+			if err := bson.Unmarshal(v, &doc); err != nil {
+				return err
+			}
+			found := false
+			for i := range doc {
+				if doc[i].Key == from {
+					doc[i].Key, found = to, true
+				}
+			}
+			if !found {
+				return nil
+			}
+			b, err := bson.Marshal(doc)
+			renamed[string(k)] = b
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for k, v := range renamed {
+			if err := b.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not rename %v into %v. Error was %v", from, to, err)
+	}
+	return nil
+}
+
+//MigrationStore records the applied migrations in the migrations bucket
+func (lib *Storage) MigrationStore() migration.Store {
+	return &migrationStore{lib.db}
+}
+
+type migrationStore struct {
+	db *bolt.DB
+}
+
+type lock struct {
+	Owner   string    `bson:"owner"`
+	Expires time.Time `bson:"expires"`
+}
+
+func (s *migrationStore) Lock(ctx context.Context, owner string, ttl time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(migrationLockBucket)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if v := b.Get(lockKey); v != nil {
+			var l lock
+			if err := bson.Unmarshal(v, &l); err != nil {
+				return err
+			}
+			if l.Owner != owner && l.Expires.After(now) {
+				return migration.ErrLocked
+			}
+		}
+		v, err := bson.Marshal(lock{owner, now.Add(ttl)})
+		if err != nil {
+			return err
+		}
+		return b.Put(lockKey, v)
+	})
+}
+
+func (s *migrationStore) Unlock(ctx context.Context, owner string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(migrationLockBucket)
+		if b == nil {
+			return nil
+		}
+		if v := b.Get(lockKey); v != nil {
+			var l lock
+			if err := bson.Unmarshal(v, &l); err != nil || l.Owner != owner {
+				return err
+			}
+		}
+		return b.Delete(lockKey)
+	})
+}
+
+func (s *migrationStore) Applied(ctx context.Context) ([]migration.Record, error) {
+	var l []migration.Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(migrationsBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var r migration.Record
+			if err := bson.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			l = append(l, r)
+			return nil
+		})
+	})
+	return l, err
+}
+
+func (s *migrationStore) Add(ctx context.Context, r migration.Record) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(migrationsBucket)
+		if err != nil {
+			return err
+		}
+		v, err := bson.Marshal(r)
+		if err != nil {
+			return err
+		}
+		return b.Put(versionKey(r.Version), v)
+	})
+}
+
+func (s *migrationStore) Remove(ctx context.Context, version int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(migrationsBucket); b != nil {
+			return b.Delete(versionKey(version))
+		}
+		return nil
+	})
+}
+
+func versionKey(version int) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(version))
+	return k
+}
@@ -0,0 +1,57 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//AddRevision records a change of an exercise, with a new id
+func (lib *Storage) AddRevision(ctx context.Context, r *storage.Revision) error {
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		b := tx.Bucket(revisionsBucket)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		r.Id = primitive.NewObjectID().Hex()
+		v, err := bson.Marshal(r)
+		if err != nil {
+			return err
+		}
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, seq)
+		return b.Put(valueKey(r.ExerciseID, k), v)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+	}
+	return nil
+}
+
+//ListRevisions returns the revisions of an exercise, oldest first
+func (lib *Storage) ListRevisions(ctx context.Context, id string) ([]*storage.Revision, error) {
+	l := []*storage.Revision{}
+	prefix := valueKey(id, nil)
+	err := lib.view(ctx, func(tx *bolt.Tx) error {
+		c := tx.Bucket(revisionsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var r *storage.Revision
+			if err := bson.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			l = append(l, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find revisions of %v. Error was %v", id, err)
+	}
+	return l, nil
+}
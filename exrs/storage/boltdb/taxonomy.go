@@ -0,0 +1,70 @@
+package boltdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+//termKey sorts the terms by type then name
+func termKey(t, name string) []byte {
+	return append(append([]byte(t), 0), name...)
+}
+
+//ListTerms obtains all the taxonomy terms of a type, or every term if the type is empty
+func (lib *Storage) ListTerms(ctx context.Context, t string) ([]*storage.Term, error) {
+	var terms []*storage.Term
+	var prefix []byte
+	if t != "" {
+		prefix = termKey(t, "")
+	}
+	err := lib.view(ctx, func(tx *bolt.Tx) error {
+		c := tx.Bucket(taxonomyBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var term *storage.Term
+			if err := bson.Unmarshal(v, &term); err != nil {
+				return err
+			}
+			terms = append(terms, term)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find terms. %v", err)
+	}
+	return terms, nil
+}
+
+//AddTerm adds a term to the taxonomy, adding an existing term is a no-op
+func (lib *Storage) AddTerm(ctx context.Context, t *storage.Term) (*storage.Term, error) {
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		b, err := bson.Marshal(t)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(taxonomyBucket).Put(termKey(t.Type, t.Name), b)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add term %v. Error was %v", t, err)
+	}
+	return t, nil
+}
+
+//RemoveTerm removes a term from the taxonomy, returns false if it did not exist
+func (lib *Storage) RemoveTerm(ctx context.Context, t string, name string) (bool, error) {
+	found := false
+	err := lib.update(ctx, func(tx *bolt.Tx) error {
+		b := tx.Bucket(taxonomyBucket)
+		k := termKey(t, name)
+		found = b.Get(k) != nil
+		return b.Delete(k)
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not remove term %s %s. Error was %v", t, name, err)
+	}
+	return found, nil
+}
@@ -0,0 +1,97 @@
+//Package cache serves exercise reads from a cache in front of an ExerciseStorage
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+//Cache keeps values for a while. The in memory one serves a single instance, a shared one lets
+//every instance reuse what the others read
+type Cache interface {
+	//Get returns the value of key, false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	//Set keeps value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	//Delete drops key, deleting a missing key is a no-op
+	Delete(ctx context.Context, key string) error
+}
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+//Memory is a Cache evicting the least recently used entries past its size
+type Memory struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+//NewMemory creates a cache of at most size entries
+func NewMemory(size int) *Memory {
+	return &Memory{size: size, order: list.New(), entries: map[string]*list.Element{}, now: time.Now}
+}
+
+//Get implements Cache
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*entry)
+	if !m.now().Before(e.expires) {
+		m.remove(el)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(el)
+	return e.value, true, nil
+}
+
+//Set implements Cache
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := m.now().Add(ttl)
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expires = value, expires
+		m.order.MoveToFront(el)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(&entry{key, value, expires})
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+//Delete implements Cache
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+//Len is the number of entries, expired ones included until they are evicted
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.entries, el.Value.(*entry).key)
+}
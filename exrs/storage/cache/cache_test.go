@@ -0,0 +1,64 @@
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	type op struct {
+		action string
+		key    string
+		at     time.Duration
+	}
+	testCases := []struct {
+		name    string
+		ops     []op
+		present []string
+		missing []string
+	}{
+		{"get what was set", []op{{"set", "a", 0}}, []string{"a"}, nil},
+		{"evicts the least recently used", []op{{"set", "a", 0}, {"set", "b", 0}, {"get", "a", 0}, {"set", "c", 0}}, []string{"a", "c"}, []string{"b"}},
+		{"expires", []op{{"set", "a", 0}, {"set", "b", 30 * time.Second}, {"get", "a", time.Minute}}, []string{"b"}, []string{"a"}},
+		{"set again renews", []op{{"set", "a", 0}, {"set", "a", 30 * time.Second}, {"get", "a", time.Minute}}, []string{"a"}, nil},
+		{"delete", []op{{"set", "a", 0}, {"delete", "a", 0}, {"delete", "b", 0}}, nil, []string{"a", "b"}},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			start := time.Now()
+			var at time.Duration
+			m := NewMemory(2)
+			m.now = func() time.Time { return start.Add(at) }
+			for _, o := range tc.ops {
+				at = o.at
+				switch o.action {
+				case "set":
+					assert.NoError(t, m.Set(ctx, o.key, []byte(o.key), time.Minute))
+				case "get":
+					m.Get(ctx, o.key)
+				case "delete":
+					assert.NoError(t, m.Delete(ctx, o.key))
+				}
+			}
+			for _, k := range tc.present {
+				v, ok, err := m.Get(ctx, k)
+				assert.NoError(t, err)
+				assert.True(t, ok, "%v should be cached", k)
+				assert.Equal(t, k, string(v))
+			}
+			for _, k := range tc.missing {
+				_, ok, _ := m.Get(ctx, k)
+				assert.False(t, ok, "%v should not be cached", k)
+			}
+			assert.LessOrEqual(t, m.Len(), 2)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	exercisePrefix = "exercise:"
+	//generationKey names the generation of the cached lists, every write moves it so the lists
+	//cached before are never read again
+	generationKey = "exercises:generation"
+)
+
+//Storage decorates an ExerciseStorage serving Read and List from a Cache. Writes go through to the
+//storage and then invalidate the cache, reads racing a write on this instance are not cached
+type Storage struct {
+	storage.ExerciseStorage
+	cache Cache
+	ttl   time.Duration
+	//mu orders the invalidations against the reads filling the cache, writes bump generation
+	mu         sync.Mutex
+	generation uint64
+	requests   *prometheus.CounterVec
+}
+
+//New wraps s, caching what it reads in c for ttl
+func New(s storage.ExerciseStorage, c Cache, ttl time.Duration) *Storage {
+	return &Storage{
+		ExerciseStorage: s,
+		cache:           c,
+		ttl:             ttl,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "exrs_storage_cache_requests_total",
+			Help: "Cache lookups of the storage by operation and result: hit, miss or error.",
+		}, []string{"operation", "result"}),
+	}
+}
+
+//Describe implements prometheus.Collector
+func (s *Storage) Describe(ch chan<- *prometheus.Desc) {
+	s.requests.Describe(ch)
+}
+
+//Collect implements prometheus.Collector
+func (s *Storage) Collect(ch chan<- prometheus.Metric) {
+	s.requests.Collect(ch)
+}
+
+//Read returns the cached exercise, reading it from the storage on a miss. Units of work read the
+//storage, they see their own writes
+func (s *Storage) Read(ctx context.Context, id string) (*storage.Exercise, error) {
+	if storage.Transaction(ctx) != nil {
+		return s.ExerciseStorage.Read(ctx, id)
+	}
+	key := exercisePrefix + id
+	var cached *storage.Exercise
+	if s.lookup(ctx, "read", key, &cached) {
+		return cached, nil
+	}
+	g := s.snapshot()
+	e, err := s.ExerciseStorage.Read(ctx, id)
+	if err != nil || e == nil {
+		return e, err
+	}
+	s.fill(ctx, g, key, e)
+	return e, nil
+}
+
+//List returns the cached page of exercises, listing them from the storage on a miss. Units of work
+//list the storage
+func (s *Storage) List(ctx context.Context, f storage.Filter) ([]*storage.Exercise, error) {
+	if storage.Transaction(ctx) != nil {
+		return s.ExerciseStorage.List(ctx, f)
+	}
+	g := s.snapshot()
+	key := fmt.Sprintf("exercises:%v:%v:%v:%v:%v", s.listGeneration(ctx), f.Difficulty, f.UpdatedAfter.UnixNano(), f.Offset, f.Limit)
+	var cached []*storage.Exercise
+	if s.lookup(ctx, "list", key, &cached) {
+		return cached, nil
+	}
+	l, err := s.ExerciseStorage.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	s.fill(ctx, g, key, l)
+	return l, nil
+}
+
+//Create creates the exercise, then invalidates the cached lists
+func (s *Storage) Create(ctx context.Context, e *storage.Exercise) (*storage.Exercise, error) {
+	r, err := s.ExerciseStorage.Create(ctx, e)
+	if err == nil {
+		s.invalidate(ctx, r.Id)
+	}
+	return r, err
+}
+
+//Update updates the exercise, then invalidates it and the cached lists. A failed update may
+//still have been applied, it invalidates too
+func (s *Storage) Update(ctx context.Context, id string, e *storage.Exercise) (*storage.Exercise, error) {
+	defer s.invalidate(ctx, id)
+	return s.ExerciseStorage.Update(ctx, id, e)
+}
+
+//Delete deletes the exercise, then invalidates it and the cached lists
+func (s *Storage) Delete(ctx context.Context, id string) (bool, error) {
+	defer s.invalidate(ctx, id)
+	return s.ExerciseStorage.Delete(ctx, id)
+}
+
+//lookup decodes the value of key into v, telling whether it was cached. Cache failures count as misses
+func (s *Storage) lookup(ctx context.Context, op, key string, v interface{}) bool {
+	b, ok, err := s.cache.Get(ctx, key)
+	if err == nil && ok {
+		err = json.Unmarshal(b, v)
+	}
+	switch {
+	case err != nil:
+		ctxzap.Extract(ctx).Warn("cache lookup failed", zap.String("key", key), zap.Error(err))
+		s.requests.WithLabelValues(op, "error").Inc()
+		return false
+	case !ok:
+		s.requests.WithLabelValues(op, "miss").Inc()
+		return false
+	}
+	s.requests.WithLabelValues(op, "hit").Inc()
+	return true
+}
+
+//snapshot returns the generation before reading the storage
+func (s *Storage) snapshot() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+//fill caches v under key unless a write went through since snapshot returned g, what was read
+//may predate it
+func (s *Storage) fill(ctx context.Context, g uint64, key string, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("could not encode cache entry", zap.String("key", key), zap.Error(err))
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.generation != g {
+		return
+	}
+	if err := s.cache.Set(ctx, key, b, s.ttl); err != nil {
+		ctxzap.Extract(ctx).Warn("could not fill cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+//listGeneration returns the generation of the cached lists, starting a new one when it is missing
+//so lists cached before an evicted generation are never read again
+func (s *Storage) listGeneration(ctx context.Context) string {
+	if b, ok, err := s.cache.Get(ctx, generationKey); err == nil && ok {
+		return string(b)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextGeneration(ctx)
+}
+
+//nextGeneration starts a new generation of cached lists, mu must be held
+func (s *Storage) nextGeneration(ctx context.Context) string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	g := hex.EncodeToString(b)
+	if err := s.cache.Set(ctx, generationKey, []byte(g), s.ttl); err != nil {
+		ctxzap.Extract(ctx).Warn("could not start a generation of cached lists", zap.Error(err))
+	}
+	return g
+}
+
+//invalidate drops the cached exercise and lists after a write, and again once the unit of work
+//of ctx commits: reads until then may have cached what the write replaced
+func (s *Storage) invalidate(ctx context.Context, id string) {
+	s.drop(ctx, id)
+	if storage.Transaction(ctx) != nil {
+		storage.AfterCommit(ctx, func() { s.drop(ctx, id) })
+	}
+}
+
+//drop removes the cached exercise and starts a new generation of cached lists
+func (s *Storage) drop(ctx context.Context, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	if err := s.cache.Delete(ctx, exercisePrefix+id); err != nil {
+		ctxzap.Extract(ctx).Error("could not invalidate cached exercise", zap.String("id", id), zap.Error(err))
+	}
+	s.nextGeneration(ctx)
+}
@@ -0,0 +1,179 @@
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+//memStorage counts the reads reaching it
+type memStorage struct {
+	exercises map[string]*storage.Exercise
+	reads     int
+	lists     int
+	next      int
+	//during runs in the middle of reads, to race writes against them
+	during func()
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{exercises: map[string]*storage.Exercise{}}
+}
+
+func (m *memStorage) Create(_ context.Context, e *storage.Exercise) (*storage.Exercise, error) {
+	m.next++
+	c := *e
+	c.Id = strconv.Itoa(m.next)
+	m.exercises[c.Id] = &c
+	return &c, nil
+}
+
+func (m *memStorage) Read(_ context.Context, id string) (*storage.Exercise, error) {
+	m.reads++
+	e, ok := m.exercises[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	c := *e
+	if m.during != nil {
+		m.during()
+	}
+	return &c, nil
+}
+
+func (m *memStorage) Update(_ context.Context, id string, e *storage.Exercise) (*storage.Exercise, error) {
+	if _, ok := m.exercises[id]; !ok {
+		return nil, errors.New("not found")
+	}
+	c := *e
+	c.Id = id
+	m.exercises[id] = &c
+	return &c, nil
+}
+
+func (m *memStorage) Delete(_ context.Context, id string) (bool, error) {
+	_, ok := m.exercises[id]
+	delete(m.exercises, id)
+	return ok, nil
+}
+
+func (m *memStorage) List(_ context.Context, f storage.Filter) ([]*storage.Exercise, error) {
+	m.lists++
+	var l []*storage.Exercise
+	for i := 1; i <= m.next; i++ {
+		if e, ok := m.exercises[strconv.Itoa(i)]; ok && (f.Difficulty == "" || e.Difficulty == f.Difficulty) {
+			l = append(l, e)
+		}
+	}
+	return l, nil
+}
+
+func (m *memStorage) Ping(context.Context) error {
+	return nil
+}
+
+func TestStorageRead(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemStorage()
+	s := New(backend, NewMemory(10), time.Minute)
+	created, _ := s.Create(ctx, &storage.Exercise{Name: "squat"})
+
+	for i := 0; i < 3; i++ {
+		e, err := s.Read(ctx, created.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, "squat", e.Name)
+	}
+	assert.Equal(t, 1, backend.reads)
+	assert.Equal(t, 2.0, testutil.ToFloat64(s.requests.WithLabelValues("read", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(s.requests.WithLabelValues("read", "miss")))
+
+	_, err := s.Update(ctx, created.Id, &storage.Exercise{Name: "front squat"})
+	assert.NoError(t, err)
+	e, _ := s.Read(ctx, created.Id)
+	assert.Equal(t, "front squat", e.Name)
+
+	_, err = s.Delete(ctx, created.Id)
+	assert.NoError(t, err)
+	_, err = s.Read(ctx, created.Id)
+	assert.Error(t, err)
+}
+
+func TestStorageList(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemStorage()
+	s := New(backend, NewMemory(10), time.Minute)
+	s.Create(ctx, &storage.Exercise{Name: "squat", Difficulty: "BEGINNER"})
+
+	for i := 0; i < 2; i++ {
+		l, err := s.List(ctx, storage.Filter{})
+		assert.NoError(t, err)
+		assert.Len(t, l, 1)
+	}
+	assert.Equal(t, 1, backend.lists)
+	s.List(ctx, storage.Filter{Difficulty: "EXPERT"})
+	assert.Equal(t, 2, backend.lists, "filters are cached apart")
+
+	s.Create(ctx, &storage.Exercise{Name: "deadlift", Difficulty: "BEGINNER"})
+	l, _ := s.List(ctx, storage.Filter{})
+	assert.Len(t, l, 2, "writes invalidate the lists")
+
+	//losing the generation must not bring back the lists cached under it
+	s.cache.Delete(ctx, generationKey)
+	s.Create(ctx, &storage.Exercise{Name: "lunge"})
+	s.cache.Delete(ctx, generationKey)
+	l, _ = s.List(ctx, storage.Filter{})
+	assert.Len(t, l, 3)
+}
+
+func TestStorageReadRacingWrite(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemStorage()
+	s := New(backend, NewMemory(10), time.Minute)
+	created, _ := s.Create(ctx, &storage.Exercise{Name: "squat"})
+
+	//the write lands after the read got the old exercise but before it fills the cache
+	backend.during = func() {
+		backend.during = nil
+		s.Update(ctx, created.Id, &storage.Exercise{Name: "front squat"})
+	}
+	e, _ := s.Read(ctx, created.Id)
+	assert.Equal(t, "squat", e.Name)
+	e, _ = s.Read(ctx, created.Id)
+	assert.Equal(t, "front squat", e.Name, "the stale read was not cached")
+}
+
+func TestStorageInTransaction(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemStorage()
+	s := New(backend, NewMemory(10), time.Minute)
+	created, _ := s.Create(ctx, &storage.Exercise{Name: "squat"})
+	unit := storage.WithTransaction(ctx, "tx")
+
+	s.Read(unit, created.Id)
+	s.Read(unit, created.Id)
+	assert.Equal(t, 2, backend.reads, "units of work read the storage")
+	s.Read(ctx, created.Id)
+	assert.Equal(t, 3, backend.reads, "nor do they fill the cache")
+
+	old := backend.exercises[created.Id]
+	_, err := s.Update(unit, created.Id, &storage.Exercise{Name: "front squat"})
+	assert.NoError(t, err)
+	//until the unit of work commits, other calls read what it replaced
+	updated := backend.exercises[created.Id]
+	backend.exercises[created.Id] = old
+	e, _ := s.Read(ctx, created.Id)
+	assert.Equal(t, "squat", e.Name)
+
+	backend.exercises[created.Id] = updated
+	storage.Committed(unit)
+	e, _ = s.Read(ctx, created.Id)
+	assert.Equal(t, "front squat", e.Name, "the commit invalidates the exercise again")
+}
@@ -0,0 +1,221 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/migration"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsColName    = "migrations"
+	migrationLockColName = "migrations_lock"
+	lockID               = "lock"
+)
+
+//Migrations of the documents stored by older versions. kinds are the valid exercise kinds and
+//provider tells the provider of a video url
+func (lib *Storage) Migrations(kinds []string, provider func(url string) string) []migration.Migration {
+	return []migration.Migration{
+		{Version: 1, Name: "kind and taxonomy", Up: func(ctx context.Context) error {
+			_, err := lib.MigrateKindAndTaxonomy(ctx, kinds)
+			return err
+		}},
+		{Version: 2, Name: "video metadata", Up: func(ctx context.Context) error {
+			_, err := lib.MigrateVideos(ctx, provider)
+			return err
+		}},
+		{Version: 3, Name: "category into categories",
+			Up: func(ctx context.Context) error {
+				return lib.renameField(ctx, "category", "categories")
+			},
+			Down: func(ctx context.Context) error {
+				return lib.renameField(ctx, "categories", "category")
+			}},
+	}
+}
+
+//renameField renames from into to on every exercise having it
+func (lib *Storage) renameField(ctx context.Context, from, to string) error {
+	_, err := lib.UpdateMany(ctx, bson.M{from: bson.M{"$exists": true}}, bson.M{"$rename": bson.M{from: to}})
+	if err != nil {
+		return fmt.Errorf("could not rename %v into %v. Error was %v", from, to, err)
+	}
+	return nil
+}
+
+//MigrationStore records the applied migrations in the migrations collection, the lock is a
+//document of the migrations_lock collection
+func (lib *Storage) MigrationStore() migration.Store {
+	db := lib.Database()
+	return &migrationStore{db.Collection(migrationsColName), db.Collection(migrationLockColName)}
+}
+
+type migrationStore struct {
+	applied *mongo.Collection
+	lock    *mongo.Collection
+}
+
+//Lock upserts the lock document unless another owner holds it and it has not expired, the
+//unique _id turns the upsert of a second owner into a duplicate key error
+func (s *migrationStore) Lock(ctx context.Context, owner string, ttl time.Duration) error {
+	now := time.Now()
+	_, err := s.lock.UpdateOne(ctx,
+		bson.M{"_id": lockID, "$or": bson.A{bson.M{"owner": owner}, bson.M{"expires": bson.M{"$lt": now}}}},
+		bson.M{"$set": bson.M{"owner": owner, "expires": now.Add(ttl)}},
+		options.Update().SetUpsert(true))
+	if isDuplicateKey(err) {
+		return migration.ErrLocked
+	}
+	if err != nil {
+		return fmt.Errorf("could not take the migration lock. Error was %v", err)
+	}
+	return nil
+}
+
+func (s *migrationStore) Unlock(ctx context.Context, owner string) error {
+	if _, err := s.lock.DeleteOne(ctx, bson.M{"_id": lockID, "owner": owner}); err != nil {
+		return fmt.Errorf("could not release the migration lock. Error was %v", err)
+	}
+	return nil
+}
+
+func (s *migrationStore) Applied(ctx context.Context) ([]migration.Record, error) {
+	cursor, err := s.applied.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var l []migration.Record
+	return l, cursor.All(ctx, &l)
+}
+
+func (s *migrationStore) Add(ctx context.Context, r migration.Record) error {
+	_, err := s.applied.InsertOne(ctx, r)
+	return err
+}
+
+func (s *migrationStore) Remove(ctx context.Context, version int) error {
+	_, err := s.applied.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
+
+func isDuplicateKey(err error) bool {
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, e := range we.WriteErrors {
+			if e.Code == 11000 {
+				return true
+			}
+		}
+	}
+	var ce mongo.CommandError
+	return errors.As(err, &ce) && ce.Code == 11000
+}
+
+//MigrationReport summarizes the changes done by MigrateKindAndTaxonomy
+type MigrationReport struct {
+	RenamedKinds    int64
+	InvalidKinds    int64
+	NormalizedKinds int64
+	Terms           int
+}
+
+//MigrateKindAndTaxonomy migrates documents stored before kinds were typed.
+//It moves the legacy "type" field into "kind", lowercases kinds, unsets the ones not in kinds
+//and seeds the taxonomy with the categories and muscle groups already in use
+func (lib *Storage) MigrateKindAndTaxonomy(ctx context.Context, kinds []string) (*MigrationReport, error) {
+	report := &MigrationReport{}
+	r, err := lib.UpdateMany(ctx,
+		bson.M{"type": bson.M{"$exists": true}, "kind": bson.M{"$exists": false}},
+		bson.M{"$rename": bson.M{"type": "kind"}})
+	if err != nil {
+		return nil, fmt.Errorf("could not rename type into kind. Error was %v", err)
+	}
+	report.RenamedKinds = r.ModifiedCount
+	//lowercase every kind, aggregation pipelines in updates are not available on older servers
+	for _, k := range kinds {
+		r, err = lib.UpdateMany(ctx,
+			bson.M{"kind": bson.M{"$regex": "^\\s*" + k + "\\s*$", "$options": "i", "$ne": k}},
+			bson.M{"$set": bson.M{"kind": k}})
+		if err != nil {
+			return nil, fmt.Errorf("could not normalize kind %s. Error was %v", k, err)
+		}
+		report.NormalizedKinds += r.ModifiedCount
+	}
+	r, err = lib.UpdateMany(ctx,
+		bson.M{"kind": bson.M{"$exists": true, "$nin": kinds}},
+		bson.M{"$unset": bson.M{"kind": ""}})
+	if err != nil {
+		return nil, fmt.Errorf("could not unset invalid kinds. Error was %v", err)
+	}
+	report.InvalidKinds = r.ModifiedCount
+	//categories are under category until the third migration
+	fields := []struct{ term, field string }{
+		{storage.TermCategory, "category"},
+		{storage.TermCategory, "categories"},
+		{storage.TermMuscleGroup, "muscle_groups"},
+	}
+	for _, f := range fields {
+		t, field := f.term, f.field
+		names, err := lib.Distinct(ctx, field, bson.M{})
+		if err != nil {
+			return nil, fmt.Errorf("could not read distinct %s. Error was %v", field, err)
+		}
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok || name == "" {
+				continue
+			}
+			if _, err = lib.AddTerm(ctx, &storage.Term{Type: t, Name: name}); err != nil {
+				return nil, err
+			}
+			report.Terms++
+		}
+	}
+	return report, nil
+}
+
+//MigrateVideos migrates documents stored when videos were plain urls.
+//Each url becomes a video whose provider is given by the provider function, videos already
+//migrated next to them are kept
+func (lib *Storage) MigrateVideos(ctx context.Context, provider func(url string) string) (int64, error) {
+	cursor, err := lib.Find(ctx, bson.M{"videos": bson.M{"$type": "string"}})
+	if err != nil {
+		return 0, fmt.Errorf("could not find legacy videos. Error was %v", err)
+	}
+	defer cursor.Close(ctx)
+	var migrated int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     interface{}   `bson:"_id"`
+			Videos []interface{} `bson:"videos"`
+		}
+		if err = cursor.Decode(&doc); err != nil {
+			return migrated, fmt.Errorf("could not read legacy videos. Error was %v", err)
+		}
+		_, err = lib.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"videos": migrateVideos(doc.Videos, provider)}})
+		if err != nil {
+			return migrated, fmt.Errorf("could not migrate videos of %v. Error was %v", doc.ID, err)
+		}
+		migrated++
+	}
+	return migrated, cursor.Err()
+}
+
+//migrateVideos turns the plain urls of videos into videos, keeping the ones already migrated as they are
+func migrateVideos(videos []interface{}, provider func(url string) string) []interface{} {
+	migrated := make([]interface{}, 0, len(videos))
+	for _, v := range videos {
+		if url, ok := v.(string); ok {
+			v = storage.Video{Provider: provider(url), URL: url}
+		}
+		migrated = append(migrated, v)
+	}
+	return migrated
+}
@@ -0,0 +1,44 @@
+// +build unit
+
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMigrateVideos(t *testing.T) {
+	provider := func(string) string { return "youtube" }
+	migrated := primitive.D{{Key: "provider", Value: "vimeo"}, {Key: "url", Value: "https://vimeo.com/1"}}
+	testCases := []struct {
+		name     string
+		videos   []interface{}
+		expected []interface{}
+	}{
+		{
+			name:     "legacy urls",
+			videos:   []interface{}{"https://youtu.be/1", "https://youtu.be/2"},
+			expected: []interface{}{storage.Video{Provider: "youtube", URL: "https://youtu.be/1"}, storage.Video{Provider: "youtube", URL: "https://youtu.be/2"}},
+		},
+		{
+			name:     "mixed with migrated videos",
+			videos:   []interface{}{migrated, "https://youtu.be/1"},
+			expected: []interface{}{migrated, storage.Video{Provider: "youtube", URL: "https://youtu.be/1"}},
+		},
+		{
+			name:     "none",
+			videos:   nil,
+			expected: []interface{}{},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, migrateVideos(tc.videos, provider))
+		})
+	}
+}
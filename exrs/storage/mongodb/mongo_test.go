@@ -3,10 +3,29 @@
 package mongodb
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage/storagetest"
+	"github.com/stretchr/testify/require"
 )
 
 //TODO: add unit tests!!
 func TestConnection(t *testing.T) {
 	t.Skip("not implemented yet")
 }
+
+//TestConformance needs the mongo of resources/mongo.yml, every test gets its own database
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Backend {
+		s, err := New(fmt.Sprintf("exrs_test_%d", time.Now().UnixNano()), DefaultOptions())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			s.Database().Drop(context.Background())
+			s.Close()
+		})
+		return s
+	})
+}
@@ -0,0 +1,92 @@
+// +build unit
+
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestClientOptions(t *testing.T) {
+	testCases := []struct {
+		name  string
+		set   func(*Options)
+		valid bool
+	}{
+		{"defaults", func(*Options) {}, true},
+		{"pool", func(o *Options) { o.MinPoolSize, o.MaxPoolSize = 5, 50 }, true},
+		{"min above max", func(o *Options) { o.MinPoolSize, o.MaxPoolSize = 50, 5 }, false},
+		{"read preference", func(o *Options) { o.ReadPreference = "secondaryPreferred" }, true},
+		{"unknown read preference", func(o *Options) { o.ReadPreference = "fastest" }, false},
+		{"read concern", func(o *Options) { o.ReadConcern = "majority" }, true},
+		{"unknown read concern", func(o *Options) { o.ReadConcern = "eventual" }, false},
+		{"unparseable uri", func(o *Options) { o.URI = "localhost:27017" }, false},
+		{"missing client certificate", func(o *Options) { o.TLS = TLSOptions{Enabled: true, Cert: "missing.pem", Key: "missing.key"} }, false},
+		{"missing CA", func(o *Options) { o.TLS = TLSOptions{Enabled: true, CA: "missing.pem"} }, false},
+		{"tls files ignored when disabled", func(o *Options) { o.TLS = TLSOptions{CA: "missing.pem"} }, true},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			o := DefaultOptions()
+			tc.set(&o)
+			_, err := o.clientOptions()
+			assert.Equal(t, tc.valid, err == nil, "%v", err)
+		})
+	}
+}
+
+func TestClientOptionsValues(t *testing.T) {
+	o := DefaultOptions()
+	o.AppName, o.ReplicaSet, o.MaxPoolSize = "tests", "rs0", 20
+	o.ServerSelectionTimeout, o.SocketTimeout = 3*time.Second, 4*time.Second
+	o.ReadPreference, o.RetryWrites = "nearest", false
+	o.TLS = TLSOptions{Enabled: true, Insecure: true}
+	c, err := o.clientOptions()
+	require.NoError(t, err)
+	assert.Equal(t, "tests", *c.AppName)
+	assert.Equal(t, "rs0", *c.ReplicaSet)
+	assert.Equal(t, uint64(20), *c.MaxPoolSize)
+	assert.Equal(t, 10*time.Second, *c.ConnectTimeout)
+	assert.Equal(t, 3*time.Second, *c.ServerSelectionTimeout)
+	assert.Equal(t, 4*time.Second, *c.SocketTimeout)
+	assert.Equal(t, readpref.NearestMode, c.ReadPreference.Mode())
+	assert.False(t, *c.RetryWrites)
+	assert.True(t, *c.RetryReads)
+	assert.True(t, c.TLSConfig.InsecureSkipVerify)
+	assert.Nil(t, c.WriteConcern, "the write concern of the uri is kept")
+}
+
+func TestWriteConcern(t *testing.T) {
+	testCases := []struct {
+		name    string
+		concern string
+		journal bool
+		w       interface{}
+	}{
+		{"none", "", false, nil},
+		{"majority", "majority", false, "majority"},
+		{"nodes", "2", false, 2},
+		{"tag set", "east", false, "east"},
+		{"journal only", "", true, nil},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			wc := Options{WriteConcern: tc.concern, Journal: tc.journal}.writeConcern()
+			if tc.concern == "" && !tc.journal {
+				assert.Nil(t, wc)
+				return
+			}
+			require.NotNil(t, wc)
+			assert.Equal(t, tc.w, wc.GetW())
+			assert.Equal(t, tc.journal, wc.GetJ())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+//PoolStats are counters of the connection pool of the mongo client
+type PoolStats struct {
+	Open             int64
+	InUse            int64
+	Created          int64
+	Closed           int64
+	CheckOutFailures int64
+}
+
+//poolMonitor keeps PoolStats up to date from the driver pool events
+type poolMonitor struct {
+	created, closed, checkedOut, checkedIn, failures int64
+}
+
+func (m *poolMonitor) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: func(e *event.PoolEvent) {
+		switch e.Type {
+		case event.ConnectionCreated:
+			atomic.AddInt64(&m.created, 1)
+		case event.ConnectionClosed:
+			atomic.AddInt64(&m.closed, 1)
+		case event.GetSucceeded:
+			atomic.AddInt64(&m.checkedOut, 1)
+		case event.ConnectionReturned:
+			atomic.AddInt64(&m.checkedIn, 1)
+		case event.GetFailed:
+			atomic.AddInt64(&m.failures, 1)
+		}
+	}}
+}
+
+func (m *poolMonitor) stats() PoolStats {
+	s := PoolStats{
+		Created:          atomic.LoadInt64(&m.created),
+		Closed:           atomic.LoadInt64(&m.closed),
+		CheckOutFailures: atomic.LoadInt64(&m.failures),
+	}
+	s.Open = s.Created - s.Closed
+	s.InUse = atomic.LoadInt64(&m.checkedOut) - atomic.LoadInt64(&m.checkedIn)
+	return s
+}
+
+//PoolStats returns the current counters of the connection pool
+func (lib *Storage) PoolStats() PoolStats {
+	return lib.pool.stats()
+}
+
+var (
+	poolOpenDesc = prometheus.NewDesc("exrs_mongo_pool_connections_open",
+		"Connections currently open in the mongo pool.", nil, nil)
+	poolInUseDesc = prometheus.NewDesc("exrs_mongo_pool_connections_in_use",
+		"Connections currently checked out of the mongo pool.", nil, nil)
+	poolCreatedDesc = prometheus.NewDesc("exrs_mongo_pool_connections_created_total",
+		"Connections created by the mongo pool.", nil, nil)
+	poolFailuresDesc = prometheus.NewDesc("exrs_mongo_pool_checkout_failures_total",
+		"Failed attempts to check a connection out of the mongo pool.", nil, nil)
+	retriesDesc = prometheus.NewDesc("exrs_mongo_retries_total",
+		"Storage operations attempted again after a transient error.", nil, nil)
+)
+
+//Describe implements prometheus.Collector exposing the pool stats and the retries
+func (lib *Storage) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolOpenDesc
+	ch <- poolInUseDesc
+	ch <- poolCreatedDesc
+	ch <- poolFailuresDesc
+	ch <- retriesDesc
+}
+
+//Collect implements prometheus.Collector exposing the pool stats and the retries
+func (lib *Storage) Collect(ch chan<- prometheus.Metric) {
+	s := lib.PoolStats()
+	ch <- prometheus.MustNewConstMetric(poolOpenDesc, prometheus.GaugeValue, float64(s.Open))
+	ch <- prometheus.MustNewConstMetric(poolInUseDesc, prometheus.GaugeValue, float64(s.InUse))
+	ch <- prometheus.MustNewConstMetric(poolCreatedDesc, prometheus.CounterValue, float64(s.Created))
+	ch <- prometheus.MustNewConstMetric(poolFailuresDesc, prometheus.CounterValue, float64(s.CheckOutFailures))
+	ch <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, float64(atomic.LoadInt64(&lib.retried)))
+}
@@ -0,0 +1,97 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+//RetryOptions of the storage operations failing with a transient error, on top of the single
+//retry of the driver
+type RetryOptions struct {
+	//Attempts of each operation including the first one, it is not retried below 2
+	Attempts int `yaml:"attempts"`
+	//InitialBackoff before the second attempt, doubling on every attempt up to MaxBackoff
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+//backoff before the attempt following attempt, half of it is random to spread the retries of
+//concurrent calls
+func (o RetryOptions) backoff(attempt int) time.Duration {
+	d := o.InitialBackoff
+	for i := 1; i < attempt && (o.MaxBackoff <= 0 || d < o.MaxBackoff); i++ {
+		d *= 2
+	}
+	if o.MaxBackoff > 0 && d > o.MaxBackoff {
+		d = o.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+//retryableCodes are the server errors of a failover or a server shutting down
+var retryableCodes = map[int32]bool{
+	6: true, 7: true, 89: true, 91: true, 189: true, 9001: true,
+	10107: true, 11600: true, 11602: true, 13435: true, 13436: true,
+}
+
+//transient tells whether err may go away by trying again
+func transient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, mongo.ErrClientDisconnected) {
+		return false
+	}
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		return ce.HasErrorLabel("NetworkError") || ce.HasErrorLabel("TransientTransactionError") ||
+			ce.HasErrorLabel("RetryableWriteError") || retryableCodes[ce.Code]
+	}
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		return we.WriteConcernError != nil && retryableCodes[int32(we.WriteConcernError.Code)]
+	}
+	var conn topology.ConnectionError
+	if errors.As(err, &conn) {
+		return true
+	}
+	//the driver flattens server selection errors into a string
+	return strings.HasPrefix(err.Error(), "server selection error")
+}
+
+//retry runs op until it succeeds, fails with an error that is not transient, runs out of
+//attempts or ctx is done. It returns the last error of op
+func retry(ctx context.Context, o RetryOptions, retried func(), op func(attempt int) error) error {
+	for attempt := 1; ; attempt++ {
+		err := op(attempt)
+		if attempt >= o.Attempts || !transient(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(o.backoff(attempt)):
+		}
+		if retried != nil {
+			retried()
+		}
+	}
+}
+
+//retry runs op with the retry options of lib, counting the retries. Operations of a unit of work
+//run once, the transaction is aborted when they fail
+func (lib *Storage) retry(ctx context.Context, op func(attempt int) error) error {
+	if storage.Transaction(ctx) != nil {
+		return op(1)
+	}
+	return retry(ctx, lib.retries, func() { atomic.AddInt64(&lib.retried, 1) }, op)
+}
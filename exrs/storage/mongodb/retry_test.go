@@ -0,0 +1,108 @@
+// +build unit
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+func TestTransient(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"nil", nil, false},
+		{"network label", mongo.CommandError{Code: 0, Labels: []string{"NetworkError"}}, true},
+		{"transaction label", mongo.CommandError{Labels: []string{"TransientTransactionError"}}, true},
+		{"not primary", mongo.CommandError{Code: 10107}, true},
+		{"shutting down", fmt.Errorf("wrapped: %w", mongo.CommandError{Code: 91}), true},
+		{"unauthorized", mongo.CommandError{Code: 13}, false},
+		{"duplicate key", mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}, false},
+		{"write concern failover", mongo.WriteException{WriteConcernError: &mongo.WriteConcernError{Code: 11602}}, true},
+		{"connection", topology.ConnectionError{ConnectionID: "1"}, true},
+		{"server selection", errors.New("server selection error: server selection timeout, current topology: { }"), true},
+		{"no documents", mongo.ErrNoDocuments, false},
+		{"disconnected", mongo.ErrClientDisconnected, false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.transient, transient(tc.err))
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	failover := mongo.CommandError{Code: 11600}
+	o := RetryOptions{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	testCases := []struct {
+		name     string
+		errs     []error
+		attempts int
+		retried  int
+		err      error
+	}{
+		{"succeeds", []error{nil}, 1, 0, nil},
+		{"recovers", []error{failover, failover, nil}, 3, 2, nil},
+		{"runs out of attempts", []error{failover, failover, failover, nil}, 3, 2, failover},
+		{"does not retry permanent errors", []error{mongo.ErrNoDocuments, nil}, 1, 0, mongo.ErrNoDocuments},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			attempts, retried := 0, 0
+			err := retry(context.Background(), o, func() { retried++ }, func(attempt int) error {
+				attempts++
+				assert.Equal(t, attempts, attempt)
+				return tc.errs[attempt-1]
+			})
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.attempts, attempts)
+			assert.Equal(t, tc.retried, retried)
+		})
+	}
+}
+
+func TestRetryStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts := 0
+	err := retry(ctx, RetryOptions{Attempts: 5, InitialBackoff: time.Hour}, nil, func(int) error {
+		attempts++
+		return mongo.CommandError{Code: 189}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestBackoff(t *testing.T) {
+	o := RetryOptions{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	testCases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, tc := range testCases {
+		for i := 0; i < 20; i++ {
+			d := o.backoff(tc.attempt)
+			assert.True(t, d >= tc.min && d <= tc.max, "attempt %v waited %v", tc.attempt, d)
+		}
+	}
+	assert.Zero(t, RetryOptions{}.backoff(3))
+}
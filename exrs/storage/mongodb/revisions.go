@@ -0,0 +1,55 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//AddRevision records a change of an exercise, with a new id
+func (lib *Storage) AddRevision(ctx context.Context, r *storage.Revision) error {
+	b, err := bson.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+	}
+	var doc bson.D
+	if err = bson.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+	}
+	id := primitive.NewObjectID()
+	doc = append(bson.D{{Key: "_id", Value: id}}, doc...)
+	err = lib.retry(ctx, func(attempt int) error {
+		_, err := lib.revisions.InsertOne(ctx, doc)
+		if attempt > 1 && isDuplicateKey(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+	}
+	r.Id = id.Hex()
+	return nil
+}
+
+//ListRevisions returns the revisions of an exercise, oldest first
+func (lib *Storage) ListRevisions(ctx context.Context, id string) ([]*storage.Revision, error) {
+	l := []*storage.Revision{}
+	err := lib.retry(ctx, func(int) error {
+		cursor, err := lib.revisions.Find(ctx, bson.D{{Key: "exercise_id", Value: id}},
+			options.Find().SetSort(bson.D{{Key: "time", Value: 1}, {Key: "_id", Value: 1}}))
+		if err != nil {
+			return err
+		}
+		l = []*storage.Revision{}
+		return cursor.All(ctx, &l)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find revisions of %v. Error was %v", id, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+//ListTerms obtains all the taxonomy terms of a type, or every term if the type is empty
+func (lib *Storage) ListTerms(ctx context.Context, t string) ([]*storage.Term, error) {
+	filter := bson.M{}
+	if t != "" {
+		filter["type"] = t
+	}
+	var terms []*storage.Term
+	err := lib.retry(ctx, func(int) error {
+		cursor, err := lib.taxonomy.Find(ctx, filter,
+			options.Find().SetSort(bson.D{{Key: "type", Value: 1}, {Key: "name", Value: 1}}))
+		if err != nil {
+			return err
+		}
+		terms = nil
+		return cursor.All(ctx, &terms)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find terms. %v", err)
+	}
+	return terms, nil
+}
+
+//AddTerm adds a term to the taxonomy, adding an existing term is a no-op
+func (lib *Storage) AddTerm(ctx context.Context, t *storage.Term) (*storage.Term, error) {
+	filter := bson.D{{Key: "type", Value: t.Type}, {Key: "name", Value: t.Name}}
+	err := lib.retry(ctx, func(int) error {
+		_, err := lib.taxonomy.UpdateOne(ctx,
+			filter,
+			bson.M{"$setOnInsert": t},
+			options.Update().SetUpsert(true))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add term %v. Error was %v", t, err)
+	}
+	return t, nil
+}
+
+//RemoveTerm removes a term from the taxonomy, returns false if it did not exist
+func (lib *Storage) RemoveTerm(ctx context.Context, t string, name string) (bool, error) {
+	filter := bson.D{{Key: "type", Value: t}, {Key: "name", Value: name}}
+	var deleted int64
+	err := lib.retry(ctx, func(int) error {
+		r, err := lib.taxonomy.DeleteOne(ctx, filter)
+		if err == nil {
+			deleted = r.DeletedCount
+		}
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not remove term %s %s. Error was %v", t, name, err)
+	}
+	return deleted > 0, nil
+}
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/maxvw8/exercise_lib/exrs/storage/mongodb"
+
+//commandTracer starts a client span for every command the driver sends, as a child of the
+//span found in the context of the operation
+type commandTracer struct {
+	spans sync.Map //request id -> trace.Span
+}
+
+func (t *commandTracer) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			_, span := otel.Tracer(instrumentationName).Start(ctx, e.DatabaseName+"."+e.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemMongoDB,
+					semconv.DBNameKey.String(e.DatabaseName),
+					semconv.DBOperationKey.String(e.CommandName),
+				))
+			t.spans.Store(e.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			t.end(e.RequestID, "")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			t.end(e.RequestID, e.Failure)
+		},
+	}
+}
+
+func (t *commandTracer) end(id int64, failure string) {
+	s, ok := t.spans.Load(id)
+	if !ok {
+		return
+	}
+	t.spans.Delete(id)
+	span := s.(trace.Span)
+	if failure != "" {
+		span.SetStatus(codes.Error, failure)
+	}
+	span.End()
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+//InTransaction runs fn in a mongo transaction, committed when it returns nil. Transactions need a
+//replica set or a sharded cluster, on a standalone server fn runs without one
+func (lib *Storage) InTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if storage.Transaction(ctx) != nil || !lib.transactions {
+		return fn(ctx)
+	}
+	var unit context.Context
+	err := lib.client.UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			//every attempt gets a unit of its own, dropping what failed attempts registered
+			unit = storage.WithTransaction(sc, sc)
+			return nil, fn(unit)
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	storage.Committed(unit)
+	return nil
+}
+
+//supportsTransactions tells whether the deployment is a replica set or a sharded cluster
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false, fmt.Errorf("could not describe the deployment. Error was %v", err)
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+//createCollections creates the collections written in units of work, servers before 4.4 can not
+//create them within a transaction
+func createCollections(ctx context.Context, db *mongo.Database, names ...string) error {
+	for _, name := range names {
+		err := db.RunCommand(ctx, bson.D{{Key: "create", Value: name}}).Err()
+		var ce mongo.CommandError
+		//48 is NamespaceExists
+		if err != nil && !(errors.As(err, &ce) && ce.Code == 48) {
+			return fmt.Errorf("could not create collection %v. Error was %v", name, err)
+		}
+	}
+	return nil
+}
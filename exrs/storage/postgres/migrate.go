@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//migrations are applied in the order of their version, the number their file name starts with.
+//Applied files must never change, changes go in a new file
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+//migrationLock serializes the servers starting together on the same database
+const migrationLock = 7146
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+func loadMigrations() ([]migration, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	var l []migration
+	for _, e := range entries {
+		version, err := strconv.Atoi(strings.SplitN(e.Name(), "_", 2)[0])
+		if err != nil {
+			return nil, fmt.Errorf("migration %v does not start with its version", e.Name())
+		}
+		b, err := migrations.ReadFile("migrations/" + e.Name())
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, migration{version, e.Name(), string(b)})
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].version < l[j].version })
+	return l, nil
+}
+
+//migrate applies the migrations the database misses, in a single transaction so a failure
+//leaves the schema as it was. It returns how many were applied
+func migrate(ctx context.Context, db *sql.DB) (int, error) {
+	l, err := loadMigrations()
+	if err != nil {
+		return 0, fmt.Errorf("could not load migrations. Error was %v", err)
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return 0, fmt.Errorf("could not lock migrations. Error was %v", err)
+	}
+	_, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version int PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return 0, fmt.Errorf("could not create schema_migrations. Error was %v", err)
+	}
+	var current int
+	if err := tx.QueryRowContext(ctx, "SELECT coalesce(max(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return 0, fmt.Errorf("could not read the schema version. Error was %v", err)
+	}
+	applied := 0
+	for _, m := range l {
+		if m.version <= current {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+			return 0, fmt.Errorf("migration %v failed. Error was %v", m.name, err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name); err != nil {
+			return 0, err
+		}
+		applied++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit migrations. Error was %v", err)
+	}
+	return applied, nil
+}
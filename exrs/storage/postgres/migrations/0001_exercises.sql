@@ -0,0 +1,50 @@
+-- ids and names sort bytewise, the way mongo sorts them
+CREATE TABLE exercises (
+    id              text COLLATE "C" PRIMARY KEY,
+    name            text NOT NULL DEFAULT '',
+    kind            text NOT NULL DEFAULT '',
+    description     text NOT NULL DEFAULT '',
+    difficulty      text NOT NULL DEFAULT '',
+    mechanics       text NOT NULL DEFAULT '',
+    force           text NOT NULL DEFAULT '',
+    categories      text[] NOT NULL DEFAULT '{}',
+    muscles         text[] NOT NULL DEFAULT '{}',
+    muscle_groups   text[] NOT NULL DEFAULT '{}',
+    images          text[] NOT NULL DEFAULT '{}',
+    image_ids       text[] NOT NULL DEFAULT '{}',
+    instructions    text[] NOT NULL DEFAULT '{}',
+    tips            text[] NOT NULL DEFAULT '{}',
+    common_mistakes text[] NOT NULL DEFAULT '{}',
+    create_time     timestamptz,
+    update_time     timestamptz,
+    created_by      text NOT NULL DEFAULT '',
+    updated_by      text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX exercises_name ON exercises (name);
+CREATE INDEX exercises_kind ON exercises (kind);
+CREATE INDEX exercises_difficulty ON exercises (difficulty, id);
+CREATE INDEX exercises_update_time ON exercises (update_time, id);
+CREATE INDEX exercises_categories ON exercises USING gin (categories);
+CREATE INDEX exercises_muscle_groups ON exercises USING gin (muscle_groups);
+
+-- durations are in nanoseconds, as time.Duration
+CREATE TABLE exercise_videos (
+    exercise_id  text COLLATE "C" NOT NULL REFERENCES exercises (id) ON DELETE CASCADE,
+    position     int NOT NULL,
+    provider     text NOT NULL DEFAULT '',
+    url          text NOT NULL DEFAULT '',
+    asset_id     text NOT NULL DEFAULT '',
+    duration     bigint NOT NULL DEFAULT 0,
+    start_offset bigint NOT NULL DEFAULT 0,
+    end_offset   bigint NOT NULL DEFAULT 0,
+    angle        text NOT NULL DEFAULT '',
+    captions     jsonb NOT NULL DEFAULT '[]',
+    PRIMARY KEY (exercise_id, position)
+);
+
+CREATE TABLE taxonomy (
+    type text COLLATE "C" NOT NULL,
+    name text COLLATE "C" NOT NULL,
+    PRIMARY KEY (type, name)
+);
@@ -0,0 +1,12 @@
+-- revisions outlive the exercises they record, the exercise after the change is kept as json
+CREATE TABLE exercise_revisions (
+    seq         bigserial PRIMARY KEY,
+    id          text COLLATE "C" NOT NULL UNIQUE,
+    exercise_id text COLLATE "C" NOT NULL,
+    action      text NOT NULL,
+    subject     text NOT NULL DEFAULT '',
+    time        timestamptz NOT NULL,
+    exercise    jsonb
+);
+
+CREATE INDEX exercise_revisions_exercise ON exercise_revisions (exercise_id, seq);
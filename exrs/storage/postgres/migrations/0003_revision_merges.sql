@@ -0,0 +1,2 @@
+-- merges record the duplicates merged into the survivor, or the survivor a duplicate was merged into
+ALTER TABLE exercise_revisions ADD COLUMN merged_with text[] NOT NULL DEFAULT '{}';
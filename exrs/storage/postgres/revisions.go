@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+//AddRevision records a change of an exercise, with a new id
+func (lib *Storage) AddRevision(ctx context.Context, r *storage.Revision) error {
+	var exercise interface{}
+	if r.Exercise != nil {
+		b, err := json.Marshal(r.Exercise)
+		if err != nil {
+			return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+		}
+		exercise = string(b)
+	}
+	id := primitive.NewObjectID().Hex()
+	_, err := lib.conn(ctx).ExecContext(ctx, `INSERT INTO exercise_revisions (id, exercise_id, action, subject, time, exercise, merged_with)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`, id, r.ExerciseID, r.Action, r.Subject, millis(r.Time), exercise, pq.Array(nonNil(r.MergedWith)))
+	if err != nil {
+		return fmt.Errorf("failed to add revision %v. Error was %v", r, err)
+	}
+	r.Id = id
+	return nil
+}
+
+//ListRevisions returns the revisions of an exercise, oldest first
+func (lib *Storage) ListRevisions(ctx context.Context, id string) ([]*storage.Revision, error) {
+	rows, err := lib.conn(ctx).QueryContext(ctx, `SELECT id, exercise_id, action, subject, time, exercise, merged_with
+		FROM exercise_revisions WHERE exercise_id = $1 ORDER BY seq`, id)
+	if err != nil {
+		return nil, fmt.Errorf("could not find revisions of %v. Error was %v", id, err)
+	}
+	defer rows.Close()
+	l := []*storage.Revision{}
+	for rows.Next() {
+		var r storage.Revision
+		var exercise []byte
+		var merged pq.StringArray
+		if err := rows.Scan(&r.Id, &r.ExerciseID, &r.Action, &r.Subject, &r.Time, &exercise, &merged); err != nil {
+			return nil, fmt.Errorf("could not parse revisions of %v. Error was %v", id, err)
+		}
+		r.Time, r.MergedWith = r.Time.UTC(), nilIfEmpty(merged)
+		if exercise != nil {
+			if err := json.Unmarshal(exercise, &r.Exercise); err != nil {
+				return nil, fmt.Errorf("could not parse revisions of %v. Error was %v", id, err)
+			}
+		}
+		l = append(l, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not parse revisions of %v. Error was %v", id, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+)
+
+//ListTerms obtains all the taxonomy terms of a type, or every term if the type is empty
+func (lib *Storage) ListTerms(ctx context.Context, t string) ([]*storage.Term, error) {
+	rows, err := lib.conn(ctx).QueryContext(ctx,
+		"SELECT type, name FROM taxonomy WHERE $1::text = '' OR type = $1 ORDER BY type, name", t)
+	if err != nil {
+		return nil, fmt.Errorf("could not find terms. %v", err)
+	}
+	defer rows.Close()
+	var terms []*storage.Term
+	for rows.Next() {
+		var term storage.Term
+		if err := rows.Scan(&term.Type, &term.Name); err != nil {
+			return nil, fmt.Errorf("could not parse terms. %v", err)
+		}
+		terms = append(terms, &term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not parse terms. %v", err)
+	}
+	return terms, nil
+}
+
+//AddTerm adds a term to the taxonomy, adding an existing term is a no-op
+func (lib *Storage) AddTerm(ctx context.Context, t *storage.Term) (*storage.Term, error) {
+	_, err := lib.conn(ctx).ExecContext(ctx,
+		"INSERT INTO taxonomy (type, name) VALUES ($1, $2) ON CONFLICT DO NOTHING", t.Type, t.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add term %v. Error was %v", t, err)
+	}
+	return t, nil
+}
+
+//RemoveTerm removes a term from the taxonomy, returns false if it did not exist
+func (lib *Storage) RemoveTerm(ctx context.Context, t string, name string) (bool, error) {
+	r, err := lib.conn(ctx).ExecContext(ctx, "DELETE FROM taxonomy WHERE type = $1 AND name = $2", t, name)
+	if err != nil {
+		return false, fmt.Errorf("could not remove term %s %s. Error was %v", t, name, err)
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("could not remove term %s %s. Error was %v", t, name, err)
+	}
+	return n > 0, nil
+}
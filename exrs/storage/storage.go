@@ -1,22 +1,151 @@
 package storage
 
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
 //ExerciseStorage defines crud for exercise
 type ExerciseStorage interface {
-	Create(*Exercise) (*Exercise, error)
-	Delete(string) (bool, error)
-	Read(string) (*Exercise, error)
-	Update(string, *Exercise) (*Exercise, error)
-	List() ([]*Exercise, error)
+	Create(context.Context, *Exercise) (*Exercise, error)
+	Delete(context.Context, string) (bool, error)
+	Read(context.Context, string) (*Exercise, error)
+	Update(context.Context, string, *Exercise) (*Exercise, error)
+	List(context.Context, Filter) ([]*Exercise, error)
+	//Ping checks the backend is reachable and answering
+	Ping(context.Context) error
+}
+
+//TaxonomyStorage manages the terms exercises can be classified with
+type TaxonomyStorage interface {
+	ListTerms(context.Context, string) ([]*Term, error)
+	AddTerm(context.Context, *Term) (*Term, error)
+	RemoveTerm(context.Context, string, string) (bool, error)
+}
+
+//RevisionStorage keeps the history of the changes of the exercises
+type RevisionStorage interface {
+	//AddRevision records a change, setting its id
+	AddRevision(context.Context, *Revision) error
+	//ListRevisions returns the revisions of an exercise, oldest first
+	ListRevisions(context.Context, string) ([]*Revision, error)
+}
+
+//Revision actions
+const (
+	RevisionCreate = "create"
+	RevisionUpdate = "update"
+	RevisionDelete = "delete"
+	RevisionMerge  = "merge"
+)
+
+//Revision records a change of an exercise, who made it and when
+type Revision struct {
+	Id         string    `bson:"_id,omitempty"`
+	ExerciseID string    `bson:"exercise_id"`
+	Action     string    `bson:"action"`
+	Subject    string    `bson:"subject,omitempty"`
+	Time       time.Time `bson:"time"`
+	//Exercise as it was after the change, nil when deleted
+	Exercise *Exercise `bson:"exercise,omitempty"`
+	//MergedWith holds, for merges, the duplicates merged into the survivor or the survivor a duplicate
+	//was merged into
+	MergedWith []string `bson:"merged_with,omitempty"`
+}
+
+//Term types of the taxonomy
+const (
+	TermCategory    = "category"
+	TermMuscleGroup = "muscle_group"
+)
+
+//Term is an entry of the taxonomy, unique by type and name
+type Term struct {
+	Type string `bson:"type"`
+	Name string `bson:"name"`
+}
+
+//Filter narrows down the exercises returned by List. Zero values match everything
+type Filter struct {
+	Difficulty string
+	//UpdatedAfter also sorts the exercises by update time, for incremental syncs
+	UpdatedAfter time.Time
+	//Offset skips the first exercises of the sorted list, Limit caps how many are returned when positive.
+	//Exercises are sorted by id unless UpdatedAfter is set, so pages are stable
+	Offset int
+	Limit  int
 }
 
 //Exercise type stored on database
 type Exercise struct {
-	Id           string   `bson:"_id,omitempty"`
-	Name         string   `bson:"name,omitempty"`
-	Kind         string   `bson:"kind,omitempty"`
-	Categories   []string `bson:"category,omitempty"`
-	Muscles      []string `bson:"muscles,omitempty"`
-	MuscleGroups []string `bson:"muscle_groups,omitempty"`
-	Images       []string `bson:"images,omitempty"`
-	Videos       []string `bson:"videos,omitempty"`
+	Id             string    `bson:"_id,omitempty"`
+	Name           string    `bson:"name,omitempty"`
+	Kind           string    `bson:"kind,omitempty"`
+	Categories     []string  `bson:"categories,omitempty"`
+	Muscles        []string  `bson:"muscles,omitempty"`
+	MuscleGroups   []string  `bson:"muscle_groups,omitempty"`
+	Images         []string  `bson:"images,omitempty"`
+	Videos         []Video   `bson:"videos,omitempty"`
+	Description    string    `bson:"description,omitempty"`
+	Instructions   []string  `bson:"instructions,omitempty"`
+	Tips           []string  `bson:"tips,omitempty"`
+	CommonMistakes []string  `bson:"common_mistakes,omitempty"`
+	Difficulty     string    `bson:"difficulty,omitempty"`
+	Mechanics      string    `bson:"mechanics,omitempty"`
+	Force          string    `bson:"force,omitempty"`
+	ImageIDs       []string  `bson:"image_ids,omitempty"`
+	CreateTime     time.Time `bson:"create_time,omitempty"`
+	UpdateTime     time.Time `bson:"update_time,omitempty"`
+	CreatedBy      string    `bson:"created_by,omitempty"`
+	UpdatedBy      string    `bson:"updated_by,omitempty"`
+}
+
+//Video of an exercise, either on a provider like youtube or uploaded as an asset
+type Video struct {
+	Provider    string         `bson:"provider,omitempty"`
+	URL         string         `bson:"url,omitempty"`
+	AssetID     string         `bson:"asset_id,omitempty"`
+	Duration    time.Duration  `bson:"duration,omitempty"`
+	StartOffset time.Duration  `bson:"start_offset,omitempty"`
+	EndOffset   time.Duration  `bson:"end_offset,omitempty"`
+	Angle       string         `bson:"angle,omitempty"`
+	Captions    []CaptionTrack `bson:"captions,omitempty"`
+}
+
+//CaptionTrack of a video
+type CaptionTrack struct {
+	Language string `bson:"language,omitempty"`
+	Label    string `bson:"label,omitempty"`
+	URL      string `bson:"url,omitempty"`
+}
+
+//Merge sets the non empty fields of update on e like a mongo $set, the backends without $set update
+//this way. The bson form of the exercises tells what is empty, as omitempty does for mongo
+func Merge(e, update *Exercise) (*Exercise, error) {
+	var doc, set bson.M
+	if err := unmarshal(e, &doc); err != nil {
+		return nil, err
+	}
+	if err := unmarshal(update, &set); err != nil {
+		return nil, err
+	}
+	for k, v := range set {
+		doc[k] = v
+	}
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var merged *Exercise
+	return merged, bson.Unmarshal(b, &merged)
+}
+
+func unmarshal(e *Exercise, doc *bson.M) error {
+	b, err := bson.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(b, doc)
 }
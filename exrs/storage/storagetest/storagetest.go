@@ -0,0 +1,334 @@
+//Package storagetest checks storage backends behave the same, every backend runs Run in its tests
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//Backend stores the exercises, the taxonomy and the revisions, in units of work
+type Backend interface {
+	storage.ExerciseStorage
+	storage.TaxonomyStorage
+	storage.RevisionStorage
+	storage.Transactor
+}
+
+//Run checks the backends open returns, open gives every test an empty one
+func Run(t *testing.T, open func(t *testing.T) Backend) {
+	tests := []struct {
+		name string
+		test func(*testing.T, Backend)
+	}{
+		{"create and read", testCreateRead},
+		{"read missing", testReadMissing},
+		{"update", testUpdate},
+		{"delete", testDelete},
+		{"list", testList},
+		{"list paged", testListPaged},
+		{"list by difficulty", testListDifficulty},
+		{"list updated after", testListUpdatedAfter},
+		{"taxonomy", testTaxonomy},
+		{"ping", testPing},
+		{"revisions", testRevisions},
+		{"transaction commit", testTransactionCommit},
+		{"transaction rollback", testTransactionRollback},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(t, open(t))
+		})
+	}
+}
+
+//base is when the test exercises were updated, at the millisecond precision backends store
+var base = time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func create(t *testing.T, b Backend, e storage.Exercise) *storage.Exercise {
+	created, err := b.Create(context.Background(), &e)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Id)
+	return created
+}
+
+func names(l []*storage.Exercise) []string {
+	n := []string{}
+	for _, e := range l {
+		n = append(n, e.Name)
+	}
+	return n
+}
+
+func testCreateRead(t *testing.T, b Backend) {
+	e := storage.Exercise{
+		Name:         "squat",
+		Kind:         "STRENGTH",
+		Categories:   []string{"legs"},
+		MuscleGroups: []string{"quadriceps", "glutes"},
+		Videos:       []storage.Video{{Provider: "YOUTUBE", URL: "https://youtu.be/x", StartOffset: 90 * time.Second}},
+		Difficulty:   "BEGINNER",
+		CreateTime:   base,
+		UpdateTime:   base,
+		CreatedBy:    "ops",
+	}
+	created := create(t, b, e)
+	read, err := b.Read(context.Background(), created.Id)
+	require.NoError(t, err)
+	e.Id = created.Id
+	assert.Equal(t, &e, read)
+	other := create(t, b, storage.Exercise{Name: "lunge"})
+	assert.NotEqual(t, created.Id, other.Id)
+}
+
+func testReadMissing(t *testing.T, b Backend) {
+	created := create(t, b, storage.Exercise{Name: "squat"})
+	_, err := b.Delete(context.Background(), created.Id)
+	require.NoError(t, err)
+	_, err = b.Read(context.Background(), created.Id)
+	assert.Error(t, err)
+	_, err = b.Read(context.Background(), "not an id")
+	assert.Error(t, err)
+}
+
+func testUpdate(t *testing.T, b Backend) {
+	ctx := context.Background()
+	created := create(t, b, storage.Exercise{Name: "squat", Kind: "STRENGTH", Difficulty: "BEGINNER", UpdateTime: base})
+	updated, err := b.Update(ctx, created.Id, &storage.Exercise{Name: "front squat", Difficulty: "INTERMEDIATE", UpdateTime: base.Add(time.Hour)})
+	require.NoError(t, err)
+	//empty fields are left as they were
+	want := &storage.Exercise{Id: created.Id, Name: "front squat", Kind: "STRENGTH", Difficulty: "INTERMEDIATE", UpdateTime: base.Add(time.Hour)}
+	assert.Equal(t, want, updated)
+	read, err := b.Read(ctx, created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, want, read)
+	l, err := b.List(ctx, storage.Filter{Difficulty: "BEGINNER"})
+	require.NoError(t, err)
+	assert.Empty(t, l, "the update moved the exercise out of BEGINNER")
+
+	_, err = b.Delete(ctx, created.Id)
+	require.NoError(t, err)
+	_, err = b.Update(ctx, created.Id, &storage.Exercise{Name: "gone"})
+	assert.Error(t, err)
+}
+
+func testDelete(t *testing.T, b Backend) {
+	ctx := context.Background()
+	created := create(t, b, storage.Exercise{Name: "squat", Difficulty: "BEGINNER", UpdateTime: base})
+	ok, err := b.Delete(ctx, created.Id)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = b.Delete(ctx, created.Id)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	for _, f := range []storage.Filter{{}, {Difficulty: "BEGINNER"}, {UpdatedAfter: base.Add(-time.Hour)}} {
+		l, err := b.List(ctx, f)
+		require.NoError(t, err)
+		assert.Empty(t, l, "filter %+v", f)
+	}
+}
+
+func testList(t *testing.T, b Backend) {
+	ctx := context.Background()
+	l, err := b.List(ctx, storage.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, l)
+	for _, n := range []string{"a", "b", "c"} {
+		create(t, b, storage.Exercise{Name: n})
+	}
+	l, err = b.List(ctx, storage.Filter{})
+	require.NoError(t, err)
+	//sorted by id, ids follow the creation order
+	assert.Equal(t, []string{"a", "b", "c"}, names(l))
+}
+
+func testListPaged(t *testing.T, b Backend) {
+	ctx := context.Background()
+	for _, n := range []string{"a", "b", "c", "d", "e"} {
+		create(t, b, storage.Exercise{Name: n})
+	}
+	testCases := []struct {
+		offset, limit int
+		want          []string
+	}{
+		{0, 2, []string{"a", "b"}},
+		{2, 2, []string{"c", "d"}},
+		{4, 2, []string{"e"}},
+		{5, 2, []string{}},
+		{3, 0, []string{"d", "e"}},
+	}
+	for _, tc := range testCases {
+		l, err := b.List(ctx, storage.Filter{Offset: tc.offset, Limit: tc.limit})
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, names(l), "offset %v limit %v", tc.offset, tc.limit)
+	}
+}
+
+func testListDifficulty(t *testing.T, b Backend) {
+	ctx := context.Background()
+	for _, e := range []storage.Exercise{
+		{Name: "a", Difficulty: "BEGINNER"},
+		{Name: "b", Difficulty: "EXPERT"},
+		{Name: "c", Difficulty: "BEGINNER"},
+		{Name: "d"},
+		{Name: "e", Difficulty: "BEGINNER"},
+	} {
+		create(t, b, e)
+	}
+	l, err := b.List(ctx, storage.Filter{Difficulty: "BEGINNER"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "e"}, names(l))
+	l, err = b.List(ctx, storage.Filter{Difficulty: "BEGINNER", Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, names(l))
+}
+
+func testListUpdatedAfter(t *testing.T, b Backend) {
+	ctx := context.Background()
+	for _, e := range []storage.Exercise{
+		{Name: "a", UpdateTime: base.Add(3 * time.Minute)},
+		{Name: "b", UpdateTime: base.Add(time.Minute), Difficulty: "EXPERT"},
+		{Name: "c", UpdateTime: base},
+		{Name: "d", UpdateTime: base.Add(time.Minute)},
+		{Name: "e", UpdateTime: base.Add(2 * time.Minute), Difficulty: "EXPERT"},
+	} {
+		create(t, b, e)
+	}
+	testCases := []struct {
+		filter storage.Filter
+		want   []string
+	}{
+		//sorted by update time then id, strictly after
+		{storage.Filter{UpdatedAfter: base}, []string{"b", "d", "e", "a"}},
+		{storage.Filter{UpdatedAfter: base.Add(-time.Millisecond)}, []string{"c", "b", "d", "e", "a"}},
+		{storage.Filter{UpdatedAfter: base.Add(3 * time.Minute)}, []string{}},
+		{storage.Filter{UpdatedAfter: base, Offset: 1, Limit: 2}, []string{"d", "e"}},
+		{storage.Filter{UpdatedAfter: base, Difficulty: "EXPERT"}, []string{"b", "e"}},
+	}
+	for _, tc := range testCases {
+		l, err := b.List(ctx, tc.filter)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, names(l), "filter %+v", tc.filter)
+	}
+}
+
+func testTaxonomy(t *testing.T, b Backend) {
+	ctx := context.Background()
+	for _, term := range []storage.Term{
+		{Type: storage.TermMuscleGroup, Name: "glutes"},
+		{Type: storage.TermCategory, Name: "legs"},
+		{Type: storage.TermCategory, Name: "arms"},
+		{Type: storage.TermCategory, Name: "legs"},
+	} {
+		term := term
+		_, err := b.AddTerm(ctx, &term)
+		require.NoError(t, err)
+	}
+	terms, err := b.ListTerms(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, []*storage.Term{
+		{Type: storage.TermCategory, Name: "arms"},
+		{Type: storage.TermCategory, Name: "legs"},
+		{Type: storage.TermMuscleGroup, Name: "glutes"},
+	}, terms)
+	terms, err = b.ListTerms(ctx, storage.TermMuscleGroup)
+	require.NoError(t, err)
+	assert.Equal(t, []*storage.Term{{Type: storage.TermMuscleGroup, Name: "glutes"}}, terms)
+
+	ok, err := b.RemoveTerm(ctx, storage.TermCategory, "legs")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = b.RemoveTerm(ctx, storage.TermCategory, "legs")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	terms, err = b.ListTerms(ctx, storage.TermCategory)
+	require.NoError(t, err)
+	assert.Equal(t, []*storage.Term{{Type: storage.TermCategory, Name: "arms"}}, terms)
+}
+
+func testPing(t *testing.T, b Backend) {
+	assert.NoError(t, b.Ping(context.Background()))
+}
+
+func testRevisions(t *testing.T, b Backend) {
+	ctx := context.Background()
+	e := create(t, b, storage.Exercise{Name: "squat"})
+	revisions := []*storage.Revision{
+		{ExerciseID: e.Id, Action: storage.RevisionCreate, Subject: "ops", Time: base, Exercise: e},
+		{ExerciseID: "other", Action: storage.RevisionCreate, Time: base},
+		{ExerciseID: e.Id, Action: storage.RevisionMerge, Subject: "ops", Time: base.Add(time.Second), MergedWith: []string{"other"}},
+	}
+	for _, r := range revisions {
+		require.NoError(t, b.AddRevision(ctx, r))
+		require.NotEmpty(t, r.Id)
+	}
+	l, err := b.ListRevisions(ctx, e.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []*storage.Revision{revisions[0], revisions[2]}, l)
+	l, err = b.ListRevisions(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, l)
+}
+
+func testTransactionCommit(t *testing.T, b Backend) {
+	ctx := context.Background()
+	var e *storage.Exercise
+	committed := false
+	err := b.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if e, err = b.Create(ctx, &storage.Exercise{Name: "squat"}); err != nil {
+			return err
+		}
+		storage.AfterCommit(ctx, func() { committed = true })
+		//nested units of work join the outer one
+		return b.InTransaction(ctx, func(ctx context.Context) error {
+			read, err := b.Read(ctx, e.Id)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, "squat", read.Name, "reads see the writes of the unit of work")
+			return b.AddRevision(ctx, &storage.Revision{ExerciseID: e.Id, Action: storage.RevisionCreate, Time: base})
+		})
+	})
+	require.NoError(t, err)
+	assert.True(t, committed)
+	_, err = b.Read(ctx, e.Id)
+	assert.NoError(t, err)
+	l, err := b.ListRevisions(ctx, e.Id)
+	require.NoError(t, err)
+	assert.Len(t, l, 1)
+}
+
+func testTransactionRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	kept := create(t, b, storage.Exercise{Name: "lunge"})
+	failure := errors.New("failure")
+	var e *storage.Exercise
+	committed := false
+	err := b.InTransaction(ctx, func(ctx context.Context) error {
+		if storage.Transaction(ctx) == nil {
+			t.Skip("the backend runs without transactions")
+		}
+		var err error
+		if e, err = b.Create(ctx, &storage.Exercise{Name: "squat"}); err != nil {
+			return err
+		}
+		if _, err = b.Delete(ctx, kept.Id); err != nil {
+			return err
+		}
+		storage.AfterCommit(ctx, func() { committed = true })
+		return failure
+	})
+	assert.Equal(t, failure, err, "the error of the unit of work is returned as is")
+	assert.False(t, committed)
+	_, err = b.Read(ctx, e.Id)
+	assert.Error(t, err, "the exercise created is rolled back")
+	_, err = b.Read(ctx, kept.Id)
+	assert.NoError(t, err, "the exercise deleted is rolled back")
+}
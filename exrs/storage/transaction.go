@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+//Transactor runs units of work spanning several storage calls
+type Transactor interface {
+	//InTransaction runs fn in a unit of work: the writes made with the ctx fn receives are committed
+	//when it returns nil and rolled back otherwise. A ctx already in a unit of work joins it
+	InTransaction(ctx context.Context, fn func(context.Context) error) error
+}
+
+//NoTransactions is the Transactor of backends without transactions, fn runs right away and what it
+//wrote before failing stays written
+type NoTransactions struct{}
+
+//InTransaction runs fn
+func (NoTransactions) InTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type unitKey struct{}
+
+//unit of work a ctx runs in
+type unit struct {
+	tx        interface{}
+	mu        sync.Mutex
+	committed []func()
+}
+
+//WithTransaction returns a ctx running in a unit of work of the backend transaction tx. Backends
+//call it in InTransaction and find tx back with Transaction
+func WithTransaction(ctx context.Context, tx interface{}) context.Context {
+	return context.WithValue(ctx, unitKey{}, &unit{tx: tx})
+}
+
+//Transaction returns the backend transaction ctx runs in, nil outside units of work
+func Transaction(ctx context.Context) interface{} {
+	if u, ok := ctx.Value(unitKey{}).(*unit); ok {
+		return u.tx
+	}
+	return nil
+}
+
+//AfterCommit runs fn once the unit of work of ctx is committed, right away outside units of work.
+//It is dropped when the unit of work is rolled back
+func AfterCommit(ctx context.Context, fn func()) {
+	u, ok := ctx.Value(unitKey{}).(*unit)
+	if !ok {
+		fn()
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.committed = append(u.committed, fn)
+}
+
+//Committed runs what AfterCommit registered on the unit of work of ctx, backends call it once its
+//transaction is committed
+func Committed(ctx context.Context) {
+	u, ok := ctx.Value(unitKey{}).(*unit)
+	if !ok {
+		return
+	}
+	u.mu.Lock()
+	l := u.committed
+	u.committed = nil
+	u.mu.Unlock()
+	for _, fn := range l {
+		fn()
+	}
+}
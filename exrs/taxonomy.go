@@ -0,0 +1,103 @@
+package exrs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//ListTaxonomyTerms returns the terms of the taxonomy, optionally of a single type
+func (s *API) ListTaxonomyTerms(ctx context.Context, req *pbexrs.ListTaxonomyTermsRequest) (*pbexrs.ListTaxonomyTermsResponse, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	log.Debugf("listing taxonomy terms of type %v", req.GetType())
+	l, err := s.taxonomy.ListTerms(ctx, enumToStorage(int32(req.GetType()), pbexrs.TaxonomyType_name))
+	if err != nil {
+		log.Warnf("failed to list taxonomy terms. Error was %v", err)
+		return &pbexrs.ListTaxonomyTermsResponse{}, err
+	}
+	terms := make([]*pbexrs.TaxonomyTerm, len(l))
+	for i, t := range l {
+		terms[i] = UnmarshallTerm(t)
+	}
+	return &pbexrs.ListTaxonomyTermsResponse{Terms: terms}, nil
+}
+
+//CreateTaxonomyTerm adds a new term to the taxonomy
+func (s *API) CreateTaxonomyTerm(ctx context.Context, req *pbexrs.CreateTaxonomyTermRequest) (*pbexrs.TaxonomyTerm, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	t, err := s.taxonomy.AddTerm(ctx, MarshallTerm(req.Term))
+	if err != nil {
+		log.Warnf("failed to add taxonomy term %v. Error was %v", req.Term, err)
+		return &pbexrs.TaxonomyTerm{}, err
+	}
+	return UnmarshallTerm(t), nil
+}
+
+//DeleteTaxonomyTerm removes a term from the taxonomy. Exercises already using it are not modified
+func (s *API) DeleteTaxonomyTerm(ctx context.Context, req *pbexrs.DeleteTaxonomyTermRequest) (*empty.Empty, error) {
+	log := ctxzap.Extract(ctx).Sugar()
+	t := enumToStorage(int32(req.GetType()), pbexrs.TaxonomyType_name)
+	ok, err := s.taxonomy.RemoveTerm(ctx, t, req.GetName())
+	if err != nil {
+		log.Warnf("failed to remove taxonomy term %v %v. Error was %v", t, req.GetName(), err)
+		return &empty.Empty{}, err
+	}
+	if !ok {
+		return &empty.Empty{}, status.Errorf(codes.NotFound, "taxonomy term %s %s not found", t, req.GetName())
+	}
+	return &empty.Empty{}, nil
+}
+
+//checkTaxonomy checks the categories and muscle groups of an exercise are part of the taxonomy
+func (s *API) checkTaxonomy(ctx context.Context, e *pbexrs.Exercise, violations *validation.Violations) error {
+	if len(e.GetCategories()) > 0 || len(e.GetMuscleGroups()) > 0 {
+		terms, err := s.taxonomy.ListTerms(ctx, "")
+		if err != nil {
+			return err
+		}
+		known := make(map[storage.Term]bool, len(terms))
+		for _, t := range terms {
+			known[*t] = true
+		}
+		for i, c := range e.GetCategories() {
+			if !known[storage.Term{Type: storage.TermCategory, Name: c}] {
+				violations.Add(fmt.Sprintf("exercise.categories[%d]", i), fmt.Sprintf("unknown category %q", c))
+			}
+		}
+		for i, mg := range e.GetMuscleGroups() {
+			if !known[storage.Term{Type: storage.TermMuscleGroup, Name: mg}] {
+				violations.Add(fmt.Sprintf("exercise.muscle_groups[%d]", i), fmt.Sprintf("unknown muscle group %q", mg))
+			}
+		}
+	}
+	return nil
+}
+
+//MarshallTerm converts a transport layer taxonomy term into a storage layer term
+func MarshallTerm(t *pbexrs.TaxonomyTerm) *storage.Term {
+	if t == nil {
+		return nil
+	}
+	return &storage.Term{
+		Type: enumToStorage(int32(t.Type), pbexrs.TaxonomyType_name),
+		Name: t.Name,
+	}
+}
+
+//UnmarshallTerm converts a storage layer taxonomy term into a transport layer term
+func UnmarshallTerm(t *storage.Term) *pbexrs.TaxonomyTerm {
+	if t == nil {
+		return nil
+	}
+	return &pbexrs.TaxonomyTerm{
+		Type: pbexrs.TaxonomyType(enumFromStorage(t.Type, pbexrs.TaxonomyType_value)),
+		Name: t.Name,
+	}
+}
@@ -0,0 +1,69 @@
+// +build unit
+
+package exrs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTaxonomy []*storage.Term
+
+func (f fakeTaxonomy) ListTerms(context.Context, string) ([]*storage.Term, error) { return f, nil }
+func (f fakeTaxonomy) AddTerm(_ context.Context, t *storage.Term) (*storage.Term, error) {
+	return t, nil
+}
+func (f fakeTaxonomy) RemoveTerm(context.Context, string, string) (bool, error) { return true, nil }
+
+func TestValidateExercise(t *testing.T) {
+	api := &API{taxonomy: fakeTaxonomy{
+		{Type: storage.TermCategory, Name: "chest"},
+		{Type: storage.TermMuscleGroup, Name: "triceps"},
+	}}
+	testCases := []struct {
+		Name       string
+		Input      *pbexrs.Exercise
+		Violations []string
+	}{
+		{
+			Name:  "valid",
+			Input: &pbexrs.Exercise{Kind: pbexrs.Kind_ANAEROBIC, Categories: []string{"chest"}, MuscleGroups: []string{"triceps"}},
+		},
+		{
+			Name:  "no terms",
+			Input: &pbexrs.Exercise{},
+		},
+		{
+			Name:       "unknown terms",
+			Input:      &pbexrs.Exercise{Categories: []string{"chest", "triceps"}, MuscleGroups: []string{"chest"}},
+			Violations: []string{"exercise.categories[1]", "exercise.muscle_groups[0]"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := api.validateExercise(context.Background(), tc.Input)
+			if tc.Violations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			st := status.Convert(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			var fields []string
+			for _, d := range st.Details() {
+				for _, v := range d.(*errdetails.BadRequest).GetFieldViolations() {
+					fields = append(fields, v.Field)
+				}
+			}
+			assert.Equal(t, tc.Violations, fields)
+		})
+	}
+}
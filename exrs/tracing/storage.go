@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//Storage decorates an ExerciseStorage with a span per operation
+type Storage struct {
+	storage.ExerciseStorage
+}
+
+//NewStorage wraps s
+func NewStorage(s storage.ExerciseStorage) *Storage {
+	return &Storage{s}
+}
+
+func start(ctx context.Context, op string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "storage."+op, trace.WithAttributes(attrs...))
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+//Create traces the creation of an exercise
+func (s *Storage) Create(ctx context.Context, e *storage.Exercise) (r *storage.Exercise, err error) {
+	ctx, span := start(ctx, "Create")
+	defer func() { end(span, err) }()
+	return s.ExerciseStorage.Create(ctx, e)
+}
+
+//Read traces reading an exercise
+func (s *Storage) Read(ctx context.Context, id string) (r *storage.Exercise, err error) {
+	ctx, span := start(ctx, "Read", attribute.String("exercise.id", id))
+	defer func() { end(span, err) }()
+	return s.ExerciseStorage.Read(ctx, id)
+}
+
+//Update traces updating an exercise
+func (s *Storage) Update(ctx context.Context, id string, e *storage.Exercise) (r *storage.Exercise, err error) {
+	ctx, span := start(ctx, "Update", attribute.String("exercise.id", id))
+	defer func() { end(span, err) }()
+	return s.ExerciseStorage.Update(ctx, id, e)
+}
+
+//Delete traces deleting an exercise
+func (s *Storage) Delete(ctx context.Context, id string) (ok bool, err error) {
+	ctx, span := start(ctx, "Delete", attribute.String("exercise.id", id))
+	defer func() { end(span, err) }()
+	return s.ExerciseStorage.Delete(ctx, id)
+}
+
+//List traces listing exercises
+func (s *Storage) List(ctx context.Context, f storage.Filter) (l []*storage.Exercise, err error) {
+	ctx, span := start(ctx, "List")
+	defer func() {
+		span.SetAttributes(attribute.Int("exercise.count", len(l)))
+		end(span, err)
+	}()
+	return s.ExerciseStorage.List(ctx, f)
+}
+
+//Ping traces checking the backend
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	ctx, span := start(ctx, "Ping")
+	defer func() { end(span, err) }()
+	return s.ExerciseStorage.Ping(ctx)
+}
@@ -0,0 +1,98 @@
+// +build unit
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type fakeStorage struct {
+	storage.ExerciseStorage
+	err error
+}
+
+func (f fakeStorage) Read(ctx context.Context, id string) (*storage.Exercise, error) {
+	return &storage.Exercise{Id: id}, f.err
+}
+
+//TestPropagation follows a request from the gateway handler, through the grpc client and server
+//interceptors, down to the storage and checks every span belongs to the same trace
+func TestPropagation(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(NewProvider(sdktrace.WithSyncer(exp)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	repo := NewStorage(fakeStorage{err: errors.New("boom")})
+
+	server := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/exrs.ExerciseService/GetExercise"}
+	//the invoker hands the outgoing metadata to the server, like the wire would
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), req, info,
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return repo.Read(ctx, "id")
+			})
+		return err
+	}
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := UnaryClientInterceptor()(r.Context(), info.FullMethod, nil, nil, nil, invoker)
+		assert.Error(t, err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/exercises/id", nil))
+
+	spans := exp.GetSpans()
+	if !assert.Len(t, spans, 4) {
+		return
+	}
+	//spans end from the innermost out
+	names := []string{"storage.Read", "exrs.ExerciseService/GetExercise", "exrs.ExerciseService/GetExercise", "HTTP GET"}
+	for i, s := range spans {
+		assert.Equal(t, names[i], s.Name)
+		assert.Equal(t, spans[3].SpanContext.TraceID(), s.SpanContext.TraceID())
+		assert.Equal(t, codes.Error, s.Status.Code)
+		if i < 3 {
+			assert.Equal(t, spans[i+1].SpanContext.SpanID(), s.Parent.SpanID())
+		}
+	}
+}
+
+//TestSetup runs sequentially, it replaces the global provider
+func TestSetup(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exporter string
+		err      bool
+	}{
+		{"none", ExporterNone, false},
+		{"default", "", false},
+		{"stdout", ExporterStdout, false},
+		{"unknown", "jaeger", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shutdown, err := Setup(tc.exporter, ioutil.Discard)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+//Package transfer moves the taxonomy and the exercises in and out of the library as json, either
+//in process through the API or remotely through a gRPC client
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/maxvw8/exercise_lib/exrs"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+//PageSize of the exercises listed by Export
+const PageSize = 500
+
+//Catalog is the part of the exercise service transfers use, *exrs.API implements it and Remote
+//adapts a client
+type Catalog interface {
+	ListTaxonomyTerms(context.Context, *pbexrs.ListTaxonomyTermsRequest) (*pbexrs.ListTaxonomyTermsResponse, error)
+	CreateTaxonomyTerm(context.Context, *pbexrs.CreateTaxonomyTermRequest) (*pbexrs.TaxonomyTerm, error)
+	ListExercises(context.Context, *pbexrs.ListExercisesRequest) (*pbexrs.ListExercisesResponse, error)
+	CreateExercise(context.Context, *pbexrs.CreateExerciseRequest) (*pbexrs.Exercise, error)
+}
+
+//Remote adapts a client of the exercise service to a Catalog, opts apply to every call
+func Remote(c pbexrs.ExerciseServiceClient, opts ...grpc.CallOption) Catalog {
+	return remote{c, opts}
+}
+
+type remote struct {
+	c    pbexrs.ExerciseServiceClient
+	opts []grpc.CallOption
+}
+
+func (r remote) ListTaxonomyTerms(ctx context.Context, req *pbexrs.ListTaxonomyTermsRequest) (*pbexrs.ListTaxonomyTermsResponse, error) {
+	return r.c.ListTaxonomyTerms(ctx, req, r.opts...)
+}
+
+func (r remote) CreateTaxonomyTerm(ctx context.Context, req *pbexrs.CreateTaxonomyTermRequest) (*pbexrs.TaxonomyTerm, error) {
+	return r.c.CreateTaxonomyTerm(ctx, req, r.opts...)
+}
+
+func (r remote) ListExercises(ctx context.Context, req *pbexrs.ListExercisesRequest) (*pbexrs.ListExercisesResponse, error) {
+	return r.c.ListExercises(ctx, req, r.opts...)
+}
+
+func (r remote) CreateExercise(ctx context.Context, req *pbexrs.CreateExerciseRequest) (*pbexrs.Exercise, error) {
+	return r.c.CreateExercise(ctx, req, r.opts...)
+}
+
+//dump is the file format of Export and Import, messages are in their canonical json form
+type dump struct {
+	Terms     []json.RawMessage `json:"terms"`
+	Exercises []json.RawMessage `json:"exercises"`
+}
+
+//Export writes the taxonomy and every exercise to w
+func Export(ctx context.Context, c Catalog, w io.Writer) (int, error) {
+	terms, err := c.ListTaxonomyTerms(ctx, &pbexrs.ListTaxonomyTermsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("could not list taxonomy. Error was %v", err)
+	}
+	d := dump{Terms: []json.RawMessage{}, Exercises: []json.RawMessage{}}
+	for _, t := range terms.Terms {
+		b, err := protojson.Marshal(t)
+		if err != nil {
+			return 0, err
+		}
+		d.Terms = append(d.Terms, b)
+	}
+	req := &pbexrs.ListExercisesRequest{PageSize: PageSize}
+	for {
+		page, err := c.ListExercises(ctx, req)
+		if err != nil {
+			return 0, fmt.Errorf("could not list exercises. Error was %v", err)
+		}
+		for _, e := range page.Exercises {
+			b, err := protojson.Marshal(e)
+			if err != nil {
+				return 0, err
+			}
+			d.Exercises = append(d.Exercises, b)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		req.PageToken = page.NextPageToken
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return 0, fmt.Errorf("could not write export. Error was %v", err)
+	}
+	return len(d.Exercises), nil
+}
+
+//Import creates the terms and the exercises read from r, as written by Export, checking them
+//against the API rules first. Exercises get new ids, it stops at the first one failing
+func Import(ctx context.Context, c Catalog, r io.Reader) (int, error) {
+	var d dump
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return 0, fmt.Errorf("could not read import. Error was %v", err)
+	}
+	for i, b := range d.Terms {
+		req := &pbexrs.CreateTaxonomyTermRequest{Term: &pbexrs.TaxonomyTerm{}}
+		if err := unmarshal(b, req.Term, req); err != nil {
+			return 0, fmt.Errorf("invalid term %d. Error was %v", i, err)
+		}
+		if _, err := c.CreateTaxonomyTerm(ctx, req); err != nil {
+			return 0, fmt.Errorf("could not import term %d. Error was %v", i, err)
+		}
+	}
+	for i, b := range d.Exercises {
+		req := &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{}}
+		if err := unmarshal(b, req.Exercise, req); err != nil {
+			return i, fmt.Errorf("invalid exercise %d. Error was %v", i, err)
+		}
+		req.Exercise.Id = ""
+		if _, err := c.CreateExercise(ctx, req); err != nil {
+			return i, fmt.Errorf("could not import exercise %d %q. Error was %v", i, req.Exercise.Name, err)
+		}
+	}
+	return len(d.Exercises), nil
+}
+
+//unmarshal reads b into m then checks req holding m against the API rules
+func unmarshal(b []byte, m proto.Message, req proto.Message) error {
+	if err := protojson.Unmarshal(b, m); err != nil {
+		return err
+	}
+	if vs := exrs.Rules.Validate(req); len(vs) > 0 {
+		return vs.Err("invalid request")
+	}
+	return nil
+}
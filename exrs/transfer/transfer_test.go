@@ -0,0 +1,85 @@
+// +build unit
+
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/maxvw8/exercise_lib/exrs"
+	"github.com/maxvw8/exercise_lib/exrs/auth"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+type memStorage struct {
+	storage.ExerciseStorage
+	exercises []*storage.Exercise
+}
+
+func (m *memStorage) Create(_ context.Context, e *storage.Exercise) (*storage.Exercise, error) {
+	e.Id = fmt.Sprintf("%024x", len(m.exercises)+1)
+	m.exercises = append(m.exercises, e)
+	return e, nil
+}
+
+func (m *memStorage) List(context.Context, storage.Filter) ([]*storage.Exercise, error) {
+	return m.exercises, nil
+}
+
+type memTaxonomy struct {
+	storage.TaxonomyStorage
+	terms []*storage.Term
+}
+
+func (m *memTaxonomy) ListTerms(context.Context, string) ([]*storage.Term, error) {
+	return m.terms, nil
+}
+
+func (m *memTaxonomy) AddTerm(_ context.Context, t *storage.Term) (*storage.Term, error) {
+	m.terms = append(m.terms, t)
+	return t, nil
+}
+
+func TestExportImport(t *testing.T) {
+	source, err := exrs.Server(&memStorage{exercises: []*storage.Exercise{
+		{Id: "5f0c6bd1a2b3c4d5e6f70001", Name: "push up", Kind: "anaerobic", Categories: []string{"chest"}, CreatedBy: "someone"},
+		{Id: "5f0c6bd1a2b3c4d5e6f70002", Name: "sit up", Kind: "aerobic"},
+	}}, &memTaxonomy{terms: []*storage.Term{{Type: storage.TermCategory, Name: "chest"}}})
+	assert.NoError(t, err)
+	var b bytes.Buffer
+	n, err := Export(context.Background(), source, &b)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	repo, taxonomy := &memStorage{}, &memTaxonomy{}
+	target, err := exrs.Server(repo, taxonomy)
+	assert.NoError(t, err)
+	n, err = Import(auth.NewContext(context.Background(), auth.Identity{Subject: "ops"}), target, &b)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []*storage.Term{{Type: storage.TermCategory, Name: "chest"}}, taxonomy.terms)
+	if assert.Len(t, repo.exercises, 2) {
+		e := repo.exercises[0]
+		assert.Equal(t, "push up", e.Name)
+		assert.Equal(t, []string{"chest"}, e.Categories)
+		assert.Equal(t, "anaerobic", e.Kind)
+		//new ids and authors, whoever imports created them here
+		assert.NotEqual(t, "5f0c6bd1a2b3c4d5e6f70001", e.Id)
+		assert.Equal(t, "ops", e.CreatedBy)
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	repo := &memStorage{}
+	api, err := exrs.Server(repo, &memTaxonomy{})
+	assert.NoError(t, err)
+	//the second exercise has no kind
+	n, err := Import(context.Background(), api, strings.NewReader(`{"exercises": [{"name": "push up", "kind": "ANAEROBIC"}, {"name": "sit up"}]}`))
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, repo.exercises, 1)
+}
@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//Rule checks the value of a field, adding violations for the given path.
+//set is false when the field can't be reached because a parent message is missing.
+//fd is nil for rules given the whole message
+type Rule func(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, set bool, vs *Violations)
+
+//Required fails on unset messages, empty strings and lists, and unspecified enums
+func Required(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, set bool, vs *Violations) {
+	if !set {
+		vs.Add(path, "is required")
+		return
+	}
+	switch {
+	case fd.IsList():
+		if v.List().Len() == 0 {
+			vs.Add(path, "is required")
+		}
+	case fd.Kind() == protoreflect.MessageKind:
+		if !v.Message().IsValid() {
+			vs.Add(path, "is required")
+		}
+	case fd.Kind() == protoreflect.StringKind:
+		if v.String() == "" {
+			vs.Add(path, "is required")
+		}
+	case fd.Kind() == protoreflect.EnumKind:
+		if v.Enum() == 0 {
+			vs.Add(path, "is required")
+		}
+	}
+}
+
+//Defined fails on enum values that are not declared in the proto
+func Defined(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, set bool, vs *Violations) {
+	if !set {
+		return
+	}
+	if fd.Enum().Values().ByNumber(v.Enum()) == nil {
+		vs.Add(path, fmt.Sprintf("unknown value %d", v.Enum()))
+	}
+}
+
+var objectID = regexp.MustCompile("^[0-9a-fA-F]{24}$")
+
+//ObjectID fails on non empty strings that are not a hex encoded object id
+var ObjectID = eachString(func(s string) string {
+	if s != "" && !objectID.MatchString(s) {
+		return "must be a 24 characters hex id"
+	}
+	return ""
+})
+
+//URL fails on non empty strings that are not absolute http(s) urls, applied to every element of lists
+var URL = eachString(func(s string) string {
+	if s == "" {
+		return ""
+	}
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "must be an absolute http or https url"
+	}
+	return ""
+})
+
+//NotBlank fails on empty strings inside lists
+var NotBlank = eachString(func(s string) string {
+	if s == "" {
+		return "must not be empty"
+	}
+	return ""
+})
+
+//MaxLen fails on strings longer than n characters, applied to every element of lists
+func MaxLen(n int) Rule {
+	return eachString(func(s string) string {
+		if utf8.RuneCountInString(s) > n {
+			return fmt.Sprintf("must be at most %d characters long", n)
+		}
+		return ""
+	})
+}
+
+//Range fails on integers outside of [min, max]
+func Range(min, max int64) Rule {
+	return func(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, set bool, vs *Violations) {
+		if !set {
+			return
+		}
+		if n := v.Int(); n < min || n > max {
+			vs.Add(path, fmt.Sprintf("must be between %d and %d", min, max))
+		}
+	}
+}
+
+//eachString lifts a string check into a rule, checking every element of repeated fields
+func eachString(check func(string) string) Rule {
+	return func(path string, fd protoreflect.FieldDescriptor, v protoreflect.Value, set bool, vs *Violations) {
+		if !set {
+			return
+		}
+		if !fd.IsList() {
+			if d := check(v.String()); d != "" {
+				vs.Add(path, d)
+			}
+			return
+		}
+		for i := 0; i < v.List().Len(); i++ {
+			if d := check(v.List().Get(i).String()); d != "" {
+				vs.Add(fmt.Sprintf("%s[%d]", path, i), d)
+			}
+		}
+	}
+}
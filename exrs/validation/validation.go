@@ -0,0 +1,170 @@
+//Package validation checks incoming requests against declarative per-message rules
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+//Rules maps a message full name to the rules of its fields. Fields are referenced by their
+//proto name and can be dotted paths to reach into nested messages, ex: "exercise.name".
+//Rules of nested messages are applied as well, so they only need to be declared once per type.
+//Rules under the empty path are given the whole message, for checks spanning several fields
+type Rules map[protoreflect.FullName]map[string][]Rule
+
+//Violations collects the invalid fields of a request
+type Violations []*errdetails.BadRequest_FieldViolation
+
+//Add appends a new violation for field
+func (v *Violations) Add(field, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+//Err builds an InvalidArgument error carrying every violation as details, nil if there are none
+func (v Violations) Err(msg string) error {
+	if len(v) == 0 {
+		return nil
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+//Check resolves every path of the rules against the registered message descriptors, failing on
+//unknown messages, misspelled fields and paths reaching through fields that are not messages
+func (r Rules) Check() error {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return fmt.Errorf("validation: unknown message %s. Error was %v", name, err)
+		}
+		md, ok := d.(protoreflect.MessageDescriptor)
+		if !ok {
+			return fmt.Errorf("validation: %s is not a message", name)
+		}
+		for path := range r[protoreflect.FullName(name)] {
+			if path == "" {
+				continue
+			}
+			if err := resolve(md, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//resolve checks that a dotted path reaches a field of md, going only through singular messages
+func resolve(md protoreflect.MessageDescriptor, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("validation: unknown field %s on %s", name, md.FullName())
+		}
+		if i == len(names)-1 {
+			break
+		}
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("validation: field %s on %s is not a message, %s can not be reached", name, md.FullName(), path)
+		}
+		md = fd.Message()
+	}
+	return nil
+}
+
+//Validate checks m against the rules, returning all the violations found
+func (r Rules) Validate(m protoreflect.ProtoMessage) Violations {
+	var v Violations
+	r.validate("", m.ProtoReflect(), &v)
+	return v
+}
+
+func (r Rules) validate(prefix string, m protoreflect.Message, v *Violations) {
+	fields := r[m.Descriptor().FullName()]
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths) //keeps violations in a stable order
+	for _, path := range paths {
+		if path == "" {
+			for _, rule := range fields[path] {
+				rule(strings.TrimSuffix(prefix, "."), nil, protoreflect.ValueOfMessage(m), true, v)
+			}
+			continue
+		}
+		fd, val, ok := lookup(m, path)
+		for _, rule := range fields[path] {
+			rule(prefix+path, fd, val, ok, v)
+		}
+	}
+	//recurse into the nested messages that are set
+	m.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind {
+			return true
+		}
+		path := prefix + string(fd.Name())
+		switch {
+		case fd.IsList():
+			for i := 0; i < val.List().Len(); i++ {
+				r.validate(fmt.Sprintf("%s[%d].", path, i), val.List().Get(i).Message(), v)
+			}
+		case !fd.IsMap():
+			r.validate(path+".", val.Message(), v)
+		}
+		return true
+	})
+}
+
+//lookup resolves a dotted path, ok is false when an intermediate message is not set.
+//Paths are known to resolve once Check passed, which UnaryServerInterceptor makes sure of
+func lookup(m protoreflect.Message, path string) (protoreflect.FieldDescriptor, protoreflect.Value, bool) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			panic(fmt.Sprintf("validation: unknown field %s on %s", name, m.Descriptor().FullName()))
+		}
+		if i == len(names)-1 {
+			return fd, m.Get(fd), true
+		}
+		if !m.Has(fd) {
+			return fd, protoreflect.Value{}, false
+		}
+		m = m.Get(fd).Message()
+	}
+	return nil, protoreflect.Value{}, false
+}
+
+//UnaryServerInterceptor rejects requests breaking the rules with InvalidArgument
+//before they reach the handler, listing every violation found. It fails when the rules don't Check
+func UnaryServerInterceptor(r Rules) (grpc.UnaryServerInterceptor, error) {
+	if err := r.Check(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m, ok := req.(protoreflect.ProtoMessage); ok {
+			if err := r.Validate(m).Err("invalid " + string(m.ProtoReflect().Descriptor().Name())); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}, nil
+}
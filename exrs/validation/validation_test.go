@@ -0,0 +1,159 @@
+// +build unit
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//described is a whole message rule asking exercises for a description
+func described(path string, _ protoreflect.FieldDescriptor, v protoreflect.Value, _ bool, vs *Violations) {
+	if v.Message().Interface().(*pbexrs.Exercise).GetDescription() == "" {
+		vs.Add(path, "needs a description")
+	}
+}
+
+var rules = Rules{
+	"pbexrs.CreateExerciseRequest": {
+		"exercise":        {Required},
+		"exercise.id":     {ObjectID},
+		"exercise.name":   {Required, MaxLen(5)},
+		"exercise.images": {URL},
+	},
+	"pbexrs.Exercise": {
+		"": {described},
+	},
+	"pbexrs.ListExercisesRequest": {
+		"page_size": {Range(0, 10)},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		input      protoreflect.ProtoMessage
+		violations []string
+	}{
+		{
+			name:       "missing message",
+			input:      &pbexrs.CreateExerciseRequest{},
+			violations: []string{"exercise: is required", "exercise.name: is required"},
+		},
+		{
+			name: "valid",
+			input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Id:          "5f1b0c7e8e3b8a0a4c6d1e2f",
+				Name:        "squat",
+				Images:      []string{"https://example.com/squat.jpg"},
+				Description: "legs",
+			}},
+		},
+		{
+			name: "invalid fields",
+			input: &pbexrs.CreateExerciseRequest{Exercise: &pbexrs.Exercise{
+				Id:     "42",
+				Name:   "deadlift",
+				Images: []string{"https://example.com/deadlift.jpg", "ftp://example.com/deadlift.jpg"},
+			}},
+			violations: []string{
+				"exercise.id: must be a 24 characters hex id",
+				"exercise.images[1]: must be an absolute http or https url",
+				"exercise.name: must be at most 5 characters long",
+				"exercise: needs a description",
+			},
+		},
+		{
+			name:       "whole message at the top",
+			input:      &pbexrs.Exercise{},
+			violations: []string{": needs a description"},
+		},
+		{
+			name:  "in range",
+			input: &pbexrs.ListExercisesRequest{PageSize: 10},
+		},
+		{
+			name:       "out of range",
+			input:      &pbexrs.ListExercisesRequest{PageSize: 11},
+			violations: []string{"page_size: must be between 0 and 10"},
+		},
+		{
+			name:  "no rules",
+			input: &pbexrs.GetExerciseRequest{Id: "42"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, v := range rules.Validate(tc.input) {
+				got = append(got, fmt.Sprintf("%s: %s", v.Field, v.Description))
+			}
+			assert.Equal(t, tc.violations, got)
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name  string
+		rules Rules
+		err   string
+	}{
+		{name: "valid", rules: rules},
+		{name: "unknown message", rules: Rules{"pbexrs.Workout": {"name": {Required}}}, err: "unknown message pbexrs.Workout"},
+		{name: "misspelled field", rules: Rules{"pbexrs.Exercise": {"nmae": {Required}}}, err: "unknown field nmae on pbexrs.Exercise"},
+		{name: "misspelled nested field", rules: Rules{"pbexrs.CreateExerciseRequest": {"exercise.nmae": {Required}}}, err: "unknown field nmae on pbexrs.Exercise"},
+		{name: "through a string", rules: Rules{"pbexrs.Exercise": {"name.first": {Required}}}, err: "field name on pbexrs.Exercise is not a message"},
+		{name: "through a list", rules: Rules{"pbexrs.Exercise": {"videos.url": {URL}}}, err: "field videos on pbexrs.Exercise is not a message"},
+	}
+	for _, tc := range testCases {
+		tc := tc //capturing test case issue with parallel execution
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.rules.Check()
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	_, err := UnaryServerInterceptor(Rules{"pbexrs.Exercise": {"nmae": {Required}}})
+	assert.Error(t, err, "misspelled rules fail when the interceptor is built")
+
+	interceptor, err := UnaryServerInterceptor(rules)
+	require.NoError(t, err)
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return req, nil
+	}
+	_, err = interceptor(context.Background(), &pbexrs.ListExercisesRequest{PageSize: 11}, &grpc.UnaryServerInfo{}, handler)
+	assert.False(t, handled)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	details := status.Convert(err).Details()
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "page_size", details[0].(*errdetails.BadRequest).FieldViolations[0].Field)
+	}
+
+	_, err = interceptor(context.Background(), &pbexrs.ListExercisesRequest{PageSize: 5}, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	assert.True(t, handled)
+}
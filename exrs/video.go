@@ -0,0 +1,179 @@
+package exrs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/maxvw8/exercise_lib/exrs/storage"
+	"github.com/maxvw8/exercise_lib/exrs/validation"
+	pbexrs "github.com/maxvw8/exercise_lib/pbexrs/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//providerHosts lists the hosts video urls of each provider may point to
+var providerHosts = map[pbexrs.VideoProvider][]string{
+	pbexrs.VideoProvider_YOUTUBE: {"youtube.com", "www.youtube.com", "m.youtube.com", "youtu.be", "www.youtube-nocookie.com"},
+	pbexrs.VideoProvider_VIMEO:   {"vimeo.com", "www.vimeo.com", "player.vimeo.com"},
+}
+
+//videoRule checks the url matches the provider and the segment fits in the video
+func videoRule(path string, _ protoreflect.FieldDescriptor, v protoreflect.Value, _ bool, vs *validation.Violations) {
+	video, ok := v.Message().Interface().(*pbexrs.Video)
+	if !ok {
+		return
+	}
+	switch video.Provider {
+	case pbexrs.VideoProvider_HOSTED:
+		if video.AssetId == "" {
+			vs.Add(path+".asset_id", "is required for hosted videos")
+		}
+	case pbexrs.VideoProvider_YOUTUBE, pbexrs.VideoProvider_VIMEO:
+		if video.AssetId != "" {
+			vs.Add(path+".asset_id", "is only allowed for hosted videos")
+		}
+		if u, err := url.Parse(video.Url); err != nil || !contains(providerHosts[video.Provider], strings.ToLower(u.Hostname())) {
+			vs.Add(path+".url", fmt.Sprintf("must be a %s url", strings.ToLower(video.Provider.String())))
+		}
+	}
+	//parse reports durations that don't parse, ok is false for them and for the ones not set
+	parse := func(field string, d *duration.Duration) (time.Duration, bool) {
+		if d == nil {
+			return 0, false
+		}
+		t, err := ptypes.Duration(d)
+		if err != nil || t < 0 {
+			vs.Add(path+"."+field, "must be a positive duration")
+			return 0, false
+		}
+		return t, true
+	}
+	total, bounded := parse("duration", video.Duration)
+	checkOffset := func(field string, d *duration.Duration) (time.Duration, bool) {
+		t, ok := parse(field, d)
+		if ok && bounded && t > total {
+			vs.Add(path+"."+field, "must not exceed the duration of the video")
+		}
+		return t, ok || d == nil
+	}
+	start, startOK := checkOffset("start_offset", video.StartOffset)
+	end, endOK := checkOffset("end_offset", video.EndOffset)
+	if video.EndOffset != nil && startOK && endOK && end <= start {
+		vs.Add(path+".end_offset", "must be after start_offset")
+	}
+}
+
+//VideoProvider guesses the storage representation of the provider hosting a video url, empty if unknown
+func VideoProvider(videoURL string) string {
+	u, err := url.Parse(videoURL)
+	if err != nil {
+		return ""
+	}
+	for p, hosts := range providerHosts {
+		if contains(hosts, strings.ToLower(u.Hostname())) {
+			return enumToStorage(int32(p), pbexrs.VideoProvider_name)
+		}
+	}
+	return ""
+}
+
+func contains(l []string, s string) bool {
+	for _, e := range l {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+//withVideoAngle drops the videos of an exercise not recorded from angle, keeping all if unspecified
+func withVideoAngle(e *pbexrs.Exercise, angle pbexrs.CameraAngle) *pbexrs.Exercise {
+	if e == nil || angle == pbexrs.CameraAngle_CAMERA_ANGLE_UNSPECIFIED {
+		return e
+	}
+	var videos []*pbexrs.Video
+	for _, v := range e.Videos {
+		if v.Angle == angle {
+			videos = append(videos, v)
+		}
+	}
+	e.Videos = videos
+	return e
+}
+
+//MarshallVideos converts transport layer videos into storage layer videos
+func MarshallVideos(l []*pbexrs.Video) []storage.Video {
+	if l == nil {
+		return nil
+	}
+	videos := make([]storage.Video, len(l))
+	for i, v := range l {
+		videos[i] = storage.Video{
+			Provider:    enumToStorage(int32(v.GetProvider()), pbexrs.VideoProvider_name),
+			URL:         v.GetUrl(),
+			AssetID:     v.GetAssetId(),
+			Duration:    fromDuration(v.GetDuration()),
+			StartOffset: fromDuration(v.GetStartOffset()),
+			EndOffset:   fromDuration(v.GetEndOffset()),
+			Angle:       enumToStorage(int32(v.GetAngle()), pbexrs.CameraAngle_name),
+		}
+		if v.GetProvider() == pbexrs.VideoProvider_HOSTED {
+			videos[i].URL = "" //resolved from the asset on every read
+		}
+		for _, c := range v.GetCaptions() {
+			videos[i].Captions = append(videos[i].Captions, storage.CaptionTrack{
+				Language: c.Language,
+				Label:    c.Label,
+				URL:      c.Url,
+			})
+		}
+	}
+	return videos
+}
+
+//UnmarshallVideos converts storage layer videos into transport layer videos
+func UnmarshallVideos(l []storage.Video) []*pbexrs.Video {
+	if l == nil {
+		return nil
+	}
+	videos := make([]*pbexrs.Video, len(l))
+	for i, v := range l {
+		videos[i] = &pbexrs.Video{
+			Provider:    pbexrs.VideoProvider(enumFromStorage(v.Provider, pbexrs.VideoProvider_value)),
+			Url:         v.URL,
+			AssetId:     v.AssetID,
+			Duration:    toDuration(v.Duration),
+			StartOffset: toDuration(v.StartOffset),
+			EndOffset:   toDuration(v.EndOffset),
+			Angle:       pbexrs.CameraAngle(enumFromStorage(v.Angle, pbexrs.CameraAngle_value)),
+		}
+		for _, c := range v.Captions {
+			videos[i].Captions = append(videos[i].Captions, &pbexrs.CaptionTrack{
+				Language: c.Language,
+				Label:    c.Label,
+				Url:      c.URL,
+			})
+		}
+	}
+	return videos
+}
+
+//fromDuration converts a proto duration, nil durations are stored as 0
+func fromDuration(d *duration.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	t, _ := ptypes.Duration(d)
+	return t
+}
+
+//toDuration converts a stored duration, 0 is returned as not set
+func toDuration(d time.Duration) *duration.Duration {
+	if d == 0 {
+		return nil
+	}
+	return ptypes.DurationProto(d)
+}
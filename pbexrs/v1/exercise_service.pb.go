@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.23.0
-// 	protoc        v3.12.2
+// 	protoc-gen-go v1.24.0
+// 	protoc        (unknown)
 // source: v1/exercise_service.proto
 
 package v1
@@ -9,7 +9,9 @@ package v1
 import (
 	context "context"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -31,6 +33,481 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type VideoProvider int32
+
+const (
+	VideoProvider_VIDEO_PROVIDER_UNSPECIFIED VideoProvider = 0
+	VideoProvider_YOUTUBE                    VideoProvider = 1
+	VideoProvider_VIMEO                      VideoProvider = 2
+	VideoProvider_HOSTED                     VideoProvider = 3
+)
+
+// Enum value maps for VideoProvider.
+var (
+	VideoProvider_name = map[int32]string{
+		0: "VIDEO_PROVIDER_UNSPECIFIED",
+		1: "YOUTUBE",
+		2: "VIMEO",
+		3: "HOSTED",
+	}
+	VideoProvider_value = map[string]int32{
+		"VIDEO_PROVIDER_UNSPECIFIED": 0,
+		"YOUTUBE":                    1,
+		"VIMEO":                      2,
+		"HOSTED":                     3,
+	}
+)
+
+func (x VideoProvider) Enum() *VideoProvider {
+	p := new(VideoProvider)
+	*p = x
+	return p
+}
+
+func (x VideoProvider) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VideoProvider) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[0].Descriptor()
+}
+
+func (VideoProvider) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[0]
+}
+
+func (x VideoProvider) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VideoProvider.Descriptor instead.
+func (VideoProvider) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{0}
+}
+
+type CameraAngle int32
+
+const (
+	CameraAngle_CAMERA_ANGLE_UNSPECIFIED CameraAngle = 0
+	CameraAngle_FRONT                    CameraAngle = 1
+	CameraAngle_SIDE                     CameraAngle = 2
+	CameraAngle_BACK                     CameraAngle = 3
+	CameraAngle_OVERHEAD                 CameraAngle = 4
+)
+
+// Enum value maps for CameraAngle.
+var (
+	CameraAngle_name = map[int32]string{
+		0: "CAMERA_ANGLE_UNSPECIFIED",
+		1: "FRONT",
+		2: "SIDE",
+		3: "BACK",
+		4: "OVERHEAD",
+	}
+	CameraAngle_value = map[string]int32{
+		"CAMERA_ANGLE_UNSPECIFIED": 0,
+		"FRONT":                    1,
+		"SIDE":                     2,
+		"BACK":                     3,
+		"OVERHEAD":                 4,
+	}
+)
+
+func (x CameraAngle) Enum() *CameraAngle {
+	p := new(CameraAngle)
+	*p = x
+	return p
+}
+
+func (x CameraAngle) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CameraAngle) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[1].Descriptor()
+}
+
+func (CameraAngle) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[1]
+}
+
+func (x CameraAngle) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CameraAngle.Descriptor instead.
+func (CameraAngle) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{1}
+}
+
+type Kind int32
+
+const (
+	Kind_KIND_UNSPECIFIED Kind = 0
+	Kind_ANAEROBIC        Kind = 1
+	Kind_AEROBIC          Kind = 2
+	Kind_FLEXIBILITY      Kind = 3
+)
+
+// Enum value maps for Kind.
+var (
+	Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "ANAEROBIC",
+		2: "AEROBIC",
+		3: "FLEXIBILITY",
+	}
+	Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"ANAEROBIC":        1,
+		"AEROBIC":          2,
+		"FLEXIBILITY":      3,
+	}
+)
+
+func (x Kind) Enum() *Kind {
+	p := new(Kind)
+	*p = x
+	return p
+}
+
+func (x Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[2].Descriptor()
+}
+
+func (Kind) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[2]
+}
+
+func (x Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Kind.Descriptor instead.
+func (Kind) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{2}
+}
+
+type Difficulty int32
+
+const (
+	Difficulty_DIFFICULTY_UNSPECIFIED Difficulty = 0
+	Difficulty_BEGINNER               Difficulty = 1
+	Difficulty_INTERMEDIATE           Difficulty = 2
+	Difficulty_ADVANCED               Difficulty = 3
+)
+
+// Enum value maps for Difficulty.
+var (
+	Difficulty_name = map[int32]string{
+		0: "DIFFICULTY_UNSPECIFIED",
+		1: "BEGINNER",
+		2: "INTERMEDIATE",
+		3: "ADVANCED",
+	}
+	Difficulty_value = map[string]int32{
+		"DIFFICULTY_UNSPECIFIED": 0,
+		"BEGINNER":               1,
+		"INTERMEDIATE":           2,
+		"ADVANCED":               3,
+	}
+)
+
+func (x Difficulty) Enum() *Difficulty {
+	p := new(Difficulty)
+	*p = x
+	return p
+}
+
+func (x Difficulty) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Difficulty) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[3].Descriptor()
+}
+
+func (Difficulty) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[3]
+}
+
+func (x Difficulty) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Difficulty.Descriptor instead.
+func (Difficulty) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{3}
+}
+
+type Mechanics int32
+
+const (
+	Mechanics_MECHANICS_UNSPECIFIED Mechanics = 0
+	Mechanics_COMPOUND              Mechanics = 1
+	Mechanics_ISOLATION             Mechanics = 2
+)
+
+// Enum value maps for Mechanics.
+var (
+	Mechanics_name = map[int32]string{
+		0: "MECHANICS_UNSPECIFIED",
+		1: "COMPOUND",
+		2: "ISOLATION",
+	}
+	Mechanics_value = map[string]int32{
+		"MECHANICS_UNSPECIFIED": 0,
+		"COMPOUND":              1,
+		"ISOLATION":             2,
+	}
+)
+
+func (x Mechanics) Enum() *Mechanics {
+	p := new(Mechanics)
+	*p = x
+	return p
+}
+
+func (x Mechanics) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mechanics) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[4].Descriptor()
+}
+
+func (Mechanics) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[4]
+}
+
+func (x Mechanics) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mechanics.Descriptor instead.
+func (Mechanics) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{4}
+}
+
+type Force int32
+
+const (
+	Force_FORCE_UNSPECIFIED Force = 0
+	Force_PUSH              Force = 1
+	Force_PULL              Force = 2
+	Force_STATIC            Force = 3
+)
+
+// Enum value maps for Force.
+var (
+	Force_name = map[int32]string{
+		0: "FORCE_UNSPECIFIED",
+		1: "PUSH",
+		2: "PULL",
+		3: "STATIC",
+	}
+	Force_value = map[string]int32{
+		"FORCE_UNSPECIFIED": 0,
+		"PUSH":              1,
+		"PULL":              2,
+		"STATIC":            3,
+	}
+)
+
+func (x Force) Enum() *Force {
+	p := new(Force)
+	*p = x
+	return p
+}
+
+func (x Force) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Force) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[5].Descriptor()
+}
+
+func (Force) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[5]
+}
+
+func (x Force) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Force.Descriptor instead.
+func (Force) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{5}
+}
+
+// Delete
+type DeletePolicy int32
+
+const (
+	// Same as RESTRICT.
+	DeletePolicy_DELETE_POLICY_UNSPECIFIED DeletePolicy = 0
+	// Refuse to delete an exercise still referenced, failing with FAILED_PRECONDITION.
+	DeletePolicy_RESTRICT DeletePolicy = 1
+	// Delete the entities referencing the exercise along with it.
+	DeletePolicy_CASCADE DeletePolicy = 2
+	// Remove the references to the exercise, keeping the entities holding them.
+	DeletePolicy_NULLIFY DeletePolicy = 3
+)
+
+// Enum value maps for DeletePolicy.
+var (
+	DeletePolicy_name = map[int32]string{
+		0: "DELETE_POLICY_UNSPECIFIED",
+		1: "RESTRICT",
+		2: "CASCADE",
+		3: "NULLIFY",
+	}
+	DeletePolicy_value = map[string]int32{
+		"DELETE_POLICY_UNSPECIFIED": 0,
+		"RESTRICT":                  1,
+		"CASCADE":                   2,
+		"NULLIFY":                   3,
+	}
+)
+
+func (x DeletePolicy) Enum() *DeletePolicy {
+	p := new(DeletePolicy)
+	*p = x
+	return p
+}
+
+func (x DeletePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeletePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[6].Descriptor()
+}
+
+func (DeletePolicy) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[6]
+}
+
+func (x DeletePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeletePolicy.Descriptor instead.
+func (DeletePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{6}
+}
+
+// Revisions
+type RevisionAction int32
+
+const (
+	RevisionAction_REVISION_ACTION_UNSPECIFIED RevisionAction = 0
+	RevisionAction_CREATE                      RevisionAction = 1
+	RevisionAction_UPDATE                      RevisionAction = 2
+	RevisionAction_DELETE                      RevisionAction = 3
+	RevisionAction_MERGE                       RevisionAction = 4
+)
+
+// Enum value maps for RevisionAction.
+var (
+	RevisionAction_name = map[int32]string{
+		0: "REVISION_ACTION_UNSPECIFIED",
+		1: "CREATE",
+		2: "UPDATE",
+		3: "DELETE",
+		4: "MERGE",
+	}
+	RevisionAction_value = map[string]int32{
+		"REVISION_ACTION_UNSPECIFIED": 0,
+		"CREATE":                      1,
+		"UPDATE":                      2,
+		"DELETE":                      3,
+		"MERGE":                       4,
+	}
+)
+
+func (x RevisionAction) Enum() *RevisionAction {
+	p := new(RevisionAction)
+	*p = x
+	return p
+}
+
+func (x RevisionAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RevisionAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[7].Descriptor()
+}
+
+func (RevisionAction) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[7]
+}
+
+func (x RevisionAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RevisionAction.Descriptor instead.
+func (RevisionAction) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{7}
+}
+
+// Taxonomy
+type TaxonomyType int32
+
+const (
+	TaxonomyType_TAXONOMY_TYPE_UNSPECIFIED TaxonomyType = 0
+	TaxonomyType_CATEGORY                  TaxonomyType = 1
+	TaxonomyType_MUSCLE_GROUP              TaxonomyType = 2
+)
+
+// Enum value maps for TaxonomyType.
+var (
+	TaxonomyType_name = map[int32]string{
+		0: "TAXONOMY_TYPE_UNSPECIFIED",
+		1: "CATEGORY",
+		2: "MUSCLE_GROUP",
+	}
+	TaxonomyType_value = map[string]int32{
+		"TAXONOMY_TYPE_UNSPECIFIED": 0,
+		"CATEGORY":                  1,
+		"MUSCLE_GROUP":              2,
+	}
+)
+
+func (x TaxonomyType) Enum() *TaxonomyType {
+	p := new(TaxonomyType)
+	*p = x
+	return p
+}
+
+func (x TaxonomyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaxonomyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_exercise_service_proto_enumTypes[8].Descriptor()
+}
+
+func (TaxonomyType) Type() protoreflect.EnumType {
+	return &file_v1_exercise_service_proto_enumTypes[8]
+}
+
+func (x TaxonomyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaxonomyType.Descriptor instead.
+func (TaxonomyType) EnumDescriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{8}
+}
+
 type Exercise struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -38,12 +515,29 @@ type Exercise struct {
 
 	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name         string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Kind         string   `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Kind         Kind     `protobuf:"varint,16,opt,name=kind,proto3,enum=pbexrs.Kind" json:"kind,omitempty"`
 	Categories   []string `protobuf:"bytes,4,rep,name=categories,proto3" json:"categories,omitempty"`
 	Muscles      []string `protobuf:"bytes,5,rep,name=muscles,proto3" json:"muscles,omitempty"`
 	MuscleGroups []string `protobuf:"bytes,6,rep,name=muscle_groups,json=muscleGroups,proto3" json:"muscle_groups,omitempty"`
 	Images       []string `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
-	Videos       []string `protobuf:"bytes,8,rep,name=videos,proto3" json:"videos,omitempty"`
+	Description  string   `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	// Ordered steps to perform the exercise.
+	Instructions   []string   `protobuf:"bytes,10,rep,name=instructions,proto3" json:"instructions,omitempty"`
+	Tips           []string   `protobuf:"bytes,11,rep,name=tips,proto3" json:"tips,omitempty"`
+	CommonMistakes []string   `protobuf:"bytes,12,rep,name=common_mistakes,json=commonMistakes,proto3" json:"common_mistakes,omitempty"`
+	Difficulty     Difficulty `protobuf:"varint,13,opt,name=difficulty,proto3,enum=pbexrs.Difficulty" json:"difficulty,omitempty"`
+	Mechanics      Mechanics  `protobuf:"varint,14,opt,name=mechanics,proto3,enum=pbexrs.Mechanics" json:"mechanics,omitempty"`
+	Force          Force      `protobuf:"varint,15,opt,name=force,proto3,enum=pbexrs.Force" json:"force,omitempty"`
+	// Ids of uploaded images, see /v1/assets
+	ImageIds []string `protobuf:"bytes,17,rep,name=image_ids,json=imageIds,proto3" json:"image_ids,omitempty"`
+	// Output only. The assets referenced by image_ids, with resolvable urls
+	ImageAssets []*Asset `protobuf:"bytes,19,rep,name=image_assets,json=imageAssets,proto3" json:"image_assets,omitempty"`
+	Videos      []*Video `protobuf:"bytes,21,rep,name=videos,proto3" json:"videos,omitempty"`
+	// Output only. Set by the server on every write
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,22,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,23,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	CreatedBy  string               `protobuf:"bytes,24,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy  string               `protobuf:"bytes,25,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
 }
 
 func (x *Exercise) Reset() {
@@ -92,11 +586,11 @@ func (x *Exercise) GetName() string {
 	return ""
 }
 
-func (x *Exercise) GetKind() string {
+func (x *Exercise) GetKind() Kind {
 	if x != nil {
 		return x.Kind
 	}
-	return ""
+	return Kind_KIND_UNSPECIFIED
 }
 
 func (x *Exercise) GetCategories() []string {
@@ -127,72 +621,204 @@ func (x *Exercise) GetImages() []string {
 	return nil
 }
 
-func (x *Exercise) GetVideos() []string {
+func (x *Exercise) GetDescription() string {
 	if x != nil {
-		return x.Videos
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-//Get
-type GetExerciseRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *Exercise) GetInstructions() []string {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
 }
 
-func (x *GetExerciseRequest) Reset() {
-	*x = GetExerciseRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_exercise_service_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Exercise) GetTips() []string {
+	if x != nil {
+		return x.Tips
 	}
+	return nil
 }
 
-func (x *GetExerciseRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Exercise) GetCommonMistakes() []string {
+	if x != nil {
+		return x.CommonMistakes
+	}
+	return nil
 }
 
-func (*GetExerciseRequest) ProtoMessage() {}
-
-func (x *GetExerciseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_exercise_service_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Exercise) GetDifficulty() Difficulty {
+	if x != nil {
+		return x.Difficulty
 	}
-	return mi.MessageOf(x)
+	return Difficulty_DIFFICULTY_UNSPECIFIED
 }
 
-// Deprecated: Use GetExerciseRequest.ProtoReflect.Descriptor instead.
-func (*GetExerciseRequest) Descriptor() ([]byte, []int) {
-	return file_v1_exercise_service_proto_rawDescGZIP(), []int{1}
+func (x *Exercise) GetMechanics() Mechanics {
+	if x != nil {
+		return x.Mechanics
+	}
+	return Mechanics_MECHANICS_UNSPECIFIED
 }
 
-func (x *GetExerciseRequest) GetId() string {
+func (x *Exercise) GetForce() Force {
 	if x != nil {
-		return x.Id
+		return x.Force
 	}
-	return ""
+	return Force_FORCE_UNSPECIFIED
 }
 
-//Create
-type CreateExerciseRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Exercise) GetImageIds() []string {
+	if x != nil {
+		return x.ImageIds
+	}
+	return nil
+}
 
-	Exercise *Exercise `protobuf:"bytes,1,opt,name=exercise,proto3" json:"exercise,omitempty"`
+func (x *Exercise) GetImageAssets() []*Asset {
+	if x != nil {
+		return x.ImageAssets
+	}
+	return nil
 }
 
-func (x *CreateExerciseRequest) Reset() {
-	*x = CreateExerciseRequest{}
+func (x *Exercise) GetVideos() []*Video {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *Exercise) GetCreateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Exercise) GetUpdateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Exercise) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Exercise) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type Asset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Url         string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// Empty for assets without thumbnail, like videos
+	ThumbnailUrl string `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Asset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Asset) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Asset) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Asset) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Asset) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+type Video struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider VideoProvider `protobuf:"varint,1,opt,name=provider,proto3,enum=pbexrs.VideoProvider" json:"provider,omitempty"`
+	// Url of the video on the provider. Output only for HOSTED videos, resolved from asset_id
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Id of the uploaded video, only for HOSTED videos
+	AssetId  string             `protobuf:"bytes,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Duration *duration.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Segment of the video showing the exercise, players should jump straight to it
+	StartOffset *duration.Duration `protobuf:"bytes,5,opt,name=start_offset,json=startOffset,proto3" json:"start_offset,omitempty"`
+	EndOffset   *duration.Duration `protobuf:"bytes,6,opt,name=end_offset,json=endOffset,proto3" json:"end_offset,omitempty"`
+	Angle       CameraAngle        `protobuf:"varint,7,opt,name=angle,proto3,enum=pbexrs.CameraAngle" json:"angle,omitempty"`
+	Captions    []*CaptionTrack    `protobuf:"bytes,8,rep,name=captions,proto3" json:"captions,omitempty"`
+}
+
+func (x *Video) Reset() {
+	*x = Video{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1_exercise_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -200,13 +826,13 @@ func (x *CreateExerciseRequest) Reset() {
 	}
 }
 
-func (x *CreateExerciseRequest) String() string {
+func (x *Video) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateExerciseRequest) ProtoMessage() {}
+func (*Video) ProtoMessage() {}
 
-func (x *CreateExerciseRequest) ProtoReflect() protoreflect.Message {
+func (x *Video) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_exercise_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -218,30 +844,81 @@ func (x *CreateExerciseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateExerciseRequest.ProtoReflect.Descriptor instead.
-func (*CreateExerciseRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Video.ProtoReflect.Descriptor instead.
+func (*Video) Descriptor() ([]byte, []int) {
 	return file_v1_exercise_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CreateExerciseRequest) GetExercise() *Exercise {
+func (x *Video) GetProvider() VideoProvider {
 	if x != nil {
-		return x.Exercise
+		return x.Provider
+	}
+	return VideoProvider_VIDEO_PROVIDER_UNSPECIFIED
+}
+
+func (x *Video) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Video) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
+}
+
+func (x *Video) GetDuration() *duration.Duration {
+	if x != nil {
+		return x.Duration
 	}
 	return nil
 }
 
-//Update
-type UpdateRequest struct {
+func (x *Video) GetStartOffset() *duration.Duration {
+	if x != nil {
+		return x.StartOffset
+	}
+	return nil
+}
+
+func (x *Video) GetEndOffset() *duration.Duration {
+	if x != nil {
+		return x.EndOffset
+	}
+	return nil
+}
+
+func (x *Video) GetAngle() CameraAngle {
+	if x != nil {
+		return x.Angle
+	}
+	return CameraAngle_CAMERA_ANGLE_UNSPECIFIED
+}
+
+func (x *Video) GetCaptions() []*CaptionTrack {
+	if x != nil {
+		return x.Captions
+	}
+	return nil
+}
+
+type CaptionTrack struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Exercise *Exercise `protobuf:"bytes,2,opt,name=exercise,proto3" json:"exercise,omitempty"`
+	// BCP 47 language tag, ex: en-US
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Label    string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// Url of a WebVTT file
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
 }
 
-func (x *UpdateRequest) Reset() {
-	*x = UpdateRequest{}
+func (x *CaptionTrack) Reset() {
+	*x = CaptionTrack{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1_exercise_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -249,13 +926,13 @@ func (x *UpdateRequest) Reset() {
 	}
 }
 
-func (x *UpdateRequest) String() string {
+func (x *CaptionTrack) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRequest) ProtoMessage() {}
+func (*CaptionTrack) ProtoMessage() {}
 
-func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+func (x *CaptionTrack) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_exercise_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -267,36 +944,45 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CaptionTrack.ProtoReflect.Descriptor instead.
+func (*CaptionTrack) Descriptor() ([]byte, []int) {
 	return file_v1_exercise_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdateRequest) GetId() string {
+func (x *CaptionTrack) GetLanguage() string {
 	if x != nil {
-		return x.Id
+		return x.Language
 	}
 	return ""
 }
 
-func (x *UpdateRequest) GetExercise() *Exercise {
+func (x *CaptionTrack) GetLabel() string {
 	if x != nil {
-		return x.Exercise
+		return x.Label
 	}
-	return nil
+	return ""
 }
 
-//Delete
-type DeleteRequest struct {
+func (x *CaptionTrack) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Get
+type GetExerciseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Only return the videos recorded from this angle, if set.
+	VideoAngle CameraAngle `protobuf:"varint,2,opt,name=video_angle,json=videoAngle,proto3,enum=pbexrs.CameraAngle" json:"video_angle,omitempty"`
 }
 
-func (x *DeleteRequest) Reset() {
-	*x = DeleteRequest{}
+func (x *GetExerciseRequest) Reset() {
+	*x = GetExerciseRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1_exercise_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -304,13 +990,13 @@ func (x *DeleteRequest) Reset() {
 	}
 }
 
-func (x *DeleteRequest) String() string {
+func (x *GetExerciseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRequest) ProtoMessage() {}
+func (*GetExerciseRequest) ProtoMessage() {}
 
-func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+func (x *GetExerciseRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_exercise_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -322,32 +1008,36 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetExerciseRequest.ProtoReflect.Descriptor instead.
+func (*GetExerciseRequest) Descriptor() ([]byte, []int) {
 	return file_v1_exercise_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DeleteRequest) GetId() string {
+func (x *GetExerciseRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-//List
-type ListExercisesRequest struct {
+func (x *GetExerciseRequest) GetVideoAngle() CameraAngle {
+	if x != nil {
+		return x.VideoAngle
+	}
+	return CameraAngle_CAMERA_ANGLE_UNSPECIFIED
+}
+
+// Create
+type CreateExerciseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The maximum number of items to return.
-	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// The next_page_token value returned from a previous List request, if any.
-	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Exercise *Exercise `protobuf:"bytes,1,opt,name=exercise,proto3" json:"exercise,omitempty"`
 }
 
-func (x *ListExercisesRequest) Reset() {
-	*x = ListExercisesRequest{}
+func (x *CreateExerciseRequest) Reset() {
+	*x = CreateExerciseRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1_exercise_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -355,13 +1045,13 @@ func (x *ListExercisesRequest) Reset() {
 	}
 }
 
-func (x *ListExercisesRequest) String() string {
+func (x *CreateExerciseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExercisesRequest) ProtoMessage() {}
+func (*CreateExerciseRequest) ProtoMessage() {}
 
-func (x *ListExercisesRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateExerciseRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_exercise_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,38 +1063,30 @@ func (x *ListExercisesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListExercisesRequest.ProtoReflect.Descriptor instead.
-func (*ListExercisesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateExerciseRequest.ProtoReflect.Descriptor instead.
+func (*CreateExerciseRequest) Descriptor() ([]byte, []int) {
 	return file_v1_exercise_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListExercisesRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListExercisesRequest) GetPageToken() string {
+func (x *CreateExerciseRequest) GetExercise() *Exercise {
 	if x != nil {
-		return x.PageToken
+		return x.Exercise
 	}
-	return ""
+	return nil
 }
 
-type ListExercisesResponse struct {
+// Update
+type UpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Exercises []*Exercise `protobuf:"bytes,1,rep,name=exercises,proto3" json:"exercises,omitempty"`
-	// Token to retrieve the next page of results, or empty if there are no
-	// more results in the list.
-	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Id       string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Exercise *Exercise `protobuf:"bytes,2,opt,name=exercise,proto3" json:"exercise,omitempty"`
 }
 
-func (x *ListExercisesResponse) Reset() {
-	*x = ListExercisesResponse{}
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_v1_exercise_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -412,180 +1094,1835 @@ func (x *ListExercisesResponse) Reset() {
 	}
 }
 
-func (x *ListExercisesResponse) String() string {
+func (x *UpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListExercisesResponse) ProtoMessage() {}
+func (*UpdateRequest) ProtoMessage() {}
 
-func (x *ListExercisesResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_v1_exercise_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListExercisesResponse.ProtoReflect.Descriptor instead.
-func (*ListExercisesResponse) Descriptor() ([]byte, []int) {
-	return file_v1_exercise_service_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *ListExercisesResponse) GetExercises() []*Exercise {
-	if x != nil {
-		return x.Exercises
-	}
-	return nil
-}
-
-func (x *ListExercisesResponse) GetNextPageToken() string {
-	if x != nil {
-		return x.NextPageToken
-	}
-	return ""
-}
-
-var File_v1_exercise_service_proto protoreflect.FileDescriptor
-
-var file_v1_exercise_service_proto_rawDesc = []byte{
-	0x0a, 0x19, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x62, 0x65,
-	0x78, 0x72, 0x73, 0x1a, 0x28, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79,
-	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x74,
-	0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd1, 0x01, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x72, 0x63,
-	0x69, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
-	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x75, 0x73, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x75,
-	0x73, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x5f,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x75,
-	0x73, 0x63, 0x6c, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67,
-	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65,
-	0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x45, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
-	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62,
-	0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x08, 0x65,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x4d, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x72,
-	0x63, 0x69, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65,
-	0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78,
-	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x52, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x6f, 0x0a, 0x15, 0x4c,
-	0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73,
-	0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63,
-	0x69, 0x73, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
-	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e,
-	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x32, 0xf1, 0x03, 0x0a,
-	0x0f, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x57, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12,
-	0x1a, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x72,
-	0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62,
-	0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1a, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63,
-	0x69, 0x73, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x62, 0x0a, 0x0e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x62,
-	0x65, 0x78, 0x72, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63,
-	0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65,
-	0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x19, 0x22, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69,
-	0x73, 0x65, 0x73, 0x3a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x5f, 0x0a,
-	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12,
-	0x15, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e,
-	0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e,
-	0x32, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x2f,
-	0x7b, 0x69, 0x64, 0x7d, 0x3a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x5b,
-	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
-	0x12, 0x15, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x63, 0x0a, 0x0d, 0x4c,
-	0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70,
-	0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
-	0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x65,
-	0x78, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x0f, 0x12, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73,
-	0x42, 0x0b, 0x5a, 0x09, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_v1_exercise_service_proto_rawDescOnce sync.Once
-	file_v1_exercise_service_proto_rawDescData = file_v1_exercise_service_proto_rawDesc
-)
-
-func file_v1_exercise_service_proto_rawDescGZIP() []byte {
-	file_v1_exercise_service_proto_rawDescOnce.Do(func() {
-		file_v1_exercise_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_exercise_service_proto_rawDescData)
-	})
-	return file_v1_exercise_service_proto_rawDescData
-}
-
-var file_v1_exercise_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_v1_exercise_service_proto_goTypes = []interface{}{
-	(*Exercise)(nil),              // 0: pbexrs.Exercise
-	(*GetExerciseRequest)(nil),    // 1: pbexrs.GetExerciseRequest
-	(*CreateExerciseRequest)(nil), // 2: pbexrs.CreateExerciseRequest
-	(*UpdateRequest)(nil),         // 3: pbexrs.UpdateRequest
-	(*DeleteRequest)(nil),         // 4: pbexrs.DeleteRequest
-	(*ListExercisesRequest)(nil),  // 5: pbexrs.ListExercisesRequest
-	(*ListExercisesResponse)(nil), // 6: pbexrs.ListExercisesResponse
-	(*empty.Empty)(nil),           // 7: google.protobuf.Empty
-}
-var file_v1_exercise_service_proto_depIdxs = []int32{
-	0, // 0: pbexrs.CreateExerciseRequest.exercise:type_name -> pbexrs.Exercise
-	0, // 1: pbexrs.UpdateRequest.exercise:type_name -> pbexrs.Exercise
-	0, // 2: pbexrs.ListExercisesResponse.exercises:type_name -> pbexrs.Exercise
-	1, // 3: pbexrs.ExerciseService.GetExercise:input_type -> pbexrs.GetExerciseRequest
-	2, // 4: pbexrs.ExerciseService.CreateExercise:input_type -> pbexrs.CreateExerciseRequest
-	3, // 5: pbexrs.ExerciseService.UpdateExercise:input_type -> pbexrs.UpdateRequest
-	4, // 6: pbexrs.ExerciseService.DeleteExercise:input_type -> pbexrs.DeleteRequest
-	5, // 7: pbexrs.ExerciseService.ListExercises:input_type -> pbexrs.ListExercisesRequest
-	0, // 8: pbexrs.ExerciseService.GetExercise:output_type -> pbexrs.Exercise
-	0, // 9: pbexrs.ExerciseService.CreateExercise:output_type -> pbexrs.Exercise
-	0, // 10: pbexrs.ExerciseService.UpdateExercise:output_type -> pbexrs.Exercise
-	7, // 11: pbexrs.ExerciseService.DeleteExercise:output_type -> google.protobuf.Empty
-	6, // 12: pbexrs.ExerciseService.ListExercises:output_type -> pbexrs.ListExercisesResponse
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_v1_exercise_service_proto_init() }
-func file_v1_exercise_service_proto_init() {
-	if File_v1_exercise_service_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_v1_exercise_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Exercise); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetExercise() *Exercise {
+	if x != nil {
+		return x.Exercise
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// What happens to the entities referencing the exercise, like workouts.
+	Policy DeletePolicy `protobuf:"varint,2,opt,name=policy,proto3,enum=pbexrs.DeletePolicy" json:"policy,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteRequest) GetPolicy() DeletePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return DeletePolicy_DELETE_POLICY_UNSPECIFIED
+}
+
+// List
+type ListExercisesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of items to return.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous List request, if any.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only return exercises of the given difficulty, if set.
+	Difficulty Difficulty `protobuf:"varint,3,opt,name=difficulty,proto3,enum=pbexrs.Difficulty" json:"difficulty,omitempty"`
+	// Only return the videos recorded from this angle, if set.
+	VideoAngle CameraAngle `protobuf:"varint,4,opt,name=video_angle,json=videoAngle,proto3,enum=pbexrs.CameraAngle" json:"video_angle,omitempty"`
+	// Only return exercises updated after this time, if set. Results are sorted by
+	// update_time so clients can sync incrementally from the last update_time seen.
+	UpdatedAfter *timestamp.Timestamp `protobuf:"bytes,5,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`
+}
+
+func (x *ListExercisesRequest) Reset() {
+	*x = ListExercisesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExercisesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExercisesRequest) ProtoMessage() {}
+
+func (x *ListExercisesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExercisesRequest.ProtoReflect.Descriptor instead.
+func (*ListExercisesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListExercisesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExercisesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListExercisesRequest) GetDifficulty() Difficulty {
+	if x != nil {
+		return x.Difficulty
+	}
+	return Difficulty_DIFFICULTY_UNSPECIFIED
+}
+
+func (x *ListExercisesRequest) GetVideoAngle() CameraAngle {
+	if x != nil {
+		return x.VideoAngle
+	}
+	return CameraAngle_CAMERA_ANGLE_UNSPECIFIED
+}
+
+func (x *ListExercisesRequest) GetUpdatedAfter() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return nil
+}
+
+type ListExercisesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exercises []*Exercise `protobuf:"bytes,1,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	// Token to retrieve the next page of results, or empty if there are no
+	// more results in the list.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListExercisesResponse) Reset() {
+	*x = ListExercisesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExercisesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExercisesResponse) ProtoMessage() {}
+
+func (x *ListExercisesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExercisesResponse.ProtoReflect.Descriptor instead.
+func (*ListExercisesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListExercisesResponse) GetExercises() []*Exercise {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *ListExercisesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ExerciseRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExerciseId string         `protobuf:"bytes,2,opt,name=exercise_id,json=exerciseId,proto3" json:"exercise_id,omitempty"`
+	Action     RevisionAction `protobuf:"varint,3,opt,name=action,proto3,enum=pbexrs.RevisionAction" json:"action,omitempty"`
+	// Subject who made the change, empty for anonymous calls.
+	Subject string               `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Time    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	// The exercise as it was after the change, unset when it was deleted.
+	Exercise *Exercise `protobuf:"bytes,6,opt,name=exercise,proto3" json:"exercise,omitempty"`
+	// For merges, the exercises merged into the survivor, or the survivor a duplicate was merged into.
+	MergedWith []string `protobuf:"bytes,7,rep,name=merged_with,json=mergedWith,proto3" json:"merged_with,omitempty"`
+}
+
+func (x *ExerciseRevision) Reset() {
+	*x = ExerciseRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExerciseRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExerciseRevision) ProtoMessage() {}
+
+func (x *ExerciseRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExerciseRevision.ProtoReflect.Descriptor instead.
+func (*ExerciseRevision) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExerciseRevision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExerciseRevision) GetExerciseId() string {
+	if x != nil {
+		return x.ExerciseId
+	}
+	return ""
+}
+
+func (x *ExerciseRevision) GetAction() RevisionAction {
+	if x != nil {
+		return x.Action
+	}
+	return RevisionAction_REVISION_ACTION_UNSPECIFIED
+}
+
+func (x *ExerciseRevision) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ExerciseRevision) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ExerciseRevision) GetExercise() *Exercise {
+	if x != nil {
+		return x.Exercise
+	}
+	return nil
+}
+
+func (x *ExerciseRevision) GetMergedWith() []string {
+	if x != nil {
+		return x.MergedWith
+	}
+	return nil
+}
+
+type ListExerciseRevisionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ListExerciseRevisionsRequest) Reset() {
+	*x = ListExerciseRevisionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExerciseRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExerciseRevisionsRequest) ProtoMessage() {}
+
+func (x *ListExerciseRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExerciseRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExerciseRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListExerciseRevisionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListExerciseRevisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*ExerciseRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+}
+
+func (x *ListExerciseRevisionsResponse) Reset() {
+	*x = ListExerciseRevisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExerciseRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExerciseRevisionsResponse) ProtoMessage() {}
+
+func (x *ListExerciseRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExerciseRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExerciseRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListExerciseRevisionsResponse) GetRevisions() []*ExerciseRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Duplicates
+type FindDuplicateExercisesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only group exercises scoring at least this percentage, 80 if unset.
+	MinScore int32 `protobuf:"varint,1,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// The maximum number of groups to return, every group if unset.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token value returned from a previous request, if any.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *FindDuplicateExercisesRequest) Reset() {
+	*x = FindDuplicateExercisesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindDuplicateExercisesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateExercisesRequest) ProtoMessage() {}
+
+func (x *FindDuplicateExercisesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateExercisesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicateExercisesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FindDuplicateExercisesRequest) GetMinScore() int32 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *FindDuplicateExercisesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FindDuplicateExercisesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type DuplicateGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exercises []*Exercise `protobuf:"bytes,1,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	// Lowest score, from 0 to 1, of the pairs of exercises that put them in the group.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Why the exercises look the same, ex: same normalized name, shared muscle groups.
+	Reasons []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+}
+
+func (x *DuplicateGroup) Reset() {
+	*x = DuplicateGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DuplicateGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateGroup) ProtoMessage() {}
+
+func (x *DuplicateGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateGroup.ProtoReflect.Descriptor instead.
+func (*DuplicateGroup) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DuplicateGroup) GetExercises() []*Exercise {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *DuplicateGroup) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DuplicateGroup) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type FindDuplicateExercisesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Most likely duplicates first.
+	Groups []*DuplicateGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	// Token to retrieve the next page of groups, or empty if there are no more groups.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *FindDuplicateExercisesResponse) Reset() {
+	*x = FindDuplicateExercisesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindDuplicateExercisesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicateExercisesResponse) ProtoMessage() {}
+
+func (x *FindDuplicateExercisesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicateExercisesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicateExercisesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FindDuplicateExercisesResponse) GetGroups() []*DuplicateGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *FindDuplicateExercisesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MergeExercisesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Exercise kept, getting the media, categories, muscles and muscle groups of the duplicates.
+	SurvivorId string `protobuf:"bytes,1,opt,name=survivor_id,json=survivorId,proto3" json:"survivor_id,omitempty"`
+	// Exercises deleted once their references point to the survivor.
+	DuplicateIds []string `protobuf:"bytes,2,rep,name=duplicate_ids,json=duplicateIds,proto3" json:"duplicate_ids,omitempty"`
+}
+
+func (x *MergeExercisesRequest) Reset() {
+	*x = MergeExercisesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeExercisesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeExercisesRequest) ProtoMessage() {}
+
+func (x *MergeExercisesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeExercisesRequest.ProtoReflect.Descriptor instead.
+func (*MergeExercisesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MergeExercisesRequest) GetSurvivorId() string {
+	if x != nil {
+		return x.SurvivorId
+	}
+	return ""
+}
+
+func (x *MergeExercisesRequest) GetDuplicateIds() []string {
+	if x != nil {
+		return x.DuplicateIds
+	}
+	return nil
+}
+
+// Recommend
+type RecommendExercisesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Muscle groups to work, matched against the muscle groups and the muscles of the exercises.
+	MuscleGroups []string `protobuf:"bytes,1,rep,name=muscle_groups,json=muscleGroups,proto3" json:"muscle_groups,omitempty"`
+	// Equipment at hand, like dumbbell. Exercises needing other equipment, told by their
+	// categories, are left out. Any equipment if empty.
+	Equipment []string `protobuf:"bytes,2,rep,name=equipment,proto3" json:"equipment,omitempty"`
+	// Difficulty wanted, harder exercises are left out. Any difficulty if unspecified.
+	Difficulty Difficulty `protobuf:"varint,3,opt,name=difficulty,proto3,enum=pbexrs.Difficulty" json:"difficulty,omitempty"`
+	// Ids of exercises not to recommend, like the ones done this week.
+	ExcludeIds []string `protobuf:"bytes,4,rep,name=exclude_ids,json=excludeIds,proto3" json:"exclude_ids,omitempty"`
+	// The maximum number of exercises to recommend, 5 if unset.
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RecommendExercisesRequest) Reset() {
+	*x = RecommendExercisesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecommendExercisesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendExercisesRequest) ProtoMessage() {}
+
+func (x *RecommendExercisesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendExercisesRequest.ProtoReflect.Descriptor instead.
+func (*RecommendExercisesRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecommendExercisesRequest) GetMuscleGroups() []string {
+	if x != nil {
+		return x.MuscleGroups
+	}
+	return nil
+}
+
+func (x *RecommendExercisesRequest) GetEquipment() []string {
+	if x != nil {
+		return x.Equipment
+	}
+	return nil
+}
+
+func (x *RecommendExercisesRequest) GetDifficulty() Difficulty {
+	if x != nil {
+		return x.Difficulty
+	}
+	return Difficulty_DIFFICULTY_UNSPECIFIED
+}
+
+func (x *RecommendExercisesRequest) GetExcludeIds() []string {
+	if x != nil {
+		return x.ExcludeIds
+	}
+	return nil
+}
+
+func (x *RecommendExercisesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Recommendation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exercise *Exercise `protobuf:"bytes,1,opt,name=exercise,proto3" json:"exercise,omitempty"`
+	// From 0 to 1, coverage of the muscle groups weighs the most, then difficulty and equipment.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Why the exercise was picked.
+	Reasons []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Recommendation) GetExercise() *Exercise {
+	if x != nil {
+		return x.Exercise
+	}
+	return nil
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Recommendation) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+type RecommendExercisesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In the order they were picked, each covering best what the previous ones missed.
+	Recommendations []*Recommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+}
+
+func (x *RecommendExercisesResponse) Reset() {
+	*x = RecommendExercisesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecommendExercisesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendExercisesResponse) ProtoMessage() {}
+
+func (x *RecommendExercisesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendExercisesResponse.ProtoReflect.Descriptor instead.
+func (*RecommendExercisesResponse) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecommendExercisesResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+type TaxonomyTerm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type TaxonomyType `protobuf:"varint,1,opt,name=type,proto3,enum=pbexrs.TaxonomyType" json:"type,omitempty"`
+	Name string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *TaxonomyTerm) Reset() {
+	*x = TaxonomyTerm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaxonomyTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxonomyTerm) ProtoMessage() {}
+
+func (x *TaxonomyTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxonomyTerm.ProtoReflect.Descriptor instead.
+func (*TaxonomyTerm) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TaxonomyTerm) GetType() TaxonomyType {
+	if x != nil {
+		return x.Type
+	}
+	return TaxonomyType_TAXONOMY_TYPE_UNSPECIFIED
+}
+
+func (x *TaxonomyTerm) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListTaxonomyTermsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The type of terms to list, all of them if unspecified.
+	Type TaxonomyType `protobuf:"varint,1,opt,name=type,proto3,enum=pbexrs.TaxonomyType" json:"type,omitempty"`
+}
+
+func (x *ListTaxonomyTermsRequest) Reset() {
+	*x = ListTaxonomyTermsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTaxonomyTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxonomyTermsRequest) ProtoMessage() {}
+
+func (x *ListTaxonomyTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxonomyTermsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaxonomyTermsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTaxonomyTermsRequest) GetType() TaxonomyType {
+	if x != nil {
+		return x.Type
+	}
+	return TaxonomyType_TAXONOMY_TYPE_UNSPECIFIED
+}
+
+type ListTaxonomyTermsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Terms []*TaxonomyTerm `protobuf:"bytes,1,rep,name=terms,proto3" json:"terms,omitempty"`
+}
+
+func (x *ListTaxonomyTermsResponse) Reset() {
+	*x = ListTaxonomyTermsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTaxonomyTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaxonomyTermsResponse) ProtoMessage() {}
+
+func (x *ListTaxonomyTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaxonomyTermsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaxonomyTermsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTaxonomyTermsResponse) GetTerms() []*TaxonomyTerm {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+type CreateTaxonomyTermRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Term *TaxonomyTerm `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+}
+
+func (x *CreateTaxonomyTermRequest) Reset() {
+	*x = CreateTaxonomyTermRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTaxonomyTermRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaxonomyTermRequest) ProtoMessage() {}
+
+func (x *CreateTaxonomyTermRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaxonomyTermRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaxonomyTermRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateTaxonomyTermRequest) GetTerm() *TaxonomyTerm {
+	if x != nil {
+		return x.Term
+	}
+	return nil
+}
+
+type DeleteTaxonomyTermRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type TaxonomyType `protobuf:"varint,1,opt,name=type,proto3,enum=pbexrs.TaxonomyType" json:"type,omitempty"`
+	Name string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteTaxonomyTermRequest) Reset() {
+	*x = DeleteTaxonomyTermRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_exercise_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTaxonomyTermRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaxonomyTermRequest) ProtoMessage() {}
+
+func (x *DeleteTaxonomyTermRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_exercise_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaxonomyTermRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaxonomyTermRequest) Descriptor() ([]byte, []int) {
+	return file_v1_exercise_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteTaxonomyTermRequest) GetType() TaxonomyType {
+	if x != nil {
+		return x.Type
+	}
+	return TaxonomyType_TAXONOMY_TYPE_UNSPECIFIED
+}
+
+func (x *DeleteTaxonomyTermRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_v1_exercise_service_proto protoreflect.FileDescriptor
+
+var file_v1_exercise_service_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x1a, 0x28, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79,
+	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x74,
+	0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2a, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61,
+	0x72, 0x74, 0x79, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x2b, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x2f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x9a, 0x06, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c,
+	0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x69,
+	0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x70, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x69, 0x70, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6d, 0x69,
+	0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x4d, 0x69, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0a,
+	0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63,
+	0x75, 0x6c, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79,
+	0x12, 0x2f, 0x0a, 0x09, 0x6d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x63, 0x73, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4d, 0x65, 0x63,
+	0x68, 0x61, 0x6e, 0x69, 0x63, 0x73, 0x52, 0x09, 0x6d, 0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x63,
+	0x73, 0x12, 0x23, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0d, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x52,
+	0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x0c, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x65, 0x78,
+	0x72, 0x73, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x41,
+	0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x18,
+	0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x56,
+	0x69, 0x64, 0x65, 0x6f, 0x52, 0x06, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x73, 0x12, 0x3b, 0x0a, 0x0b,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x42, 0x79, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x4a, 0x04, 0x08, 0x08, 0x10, 0x09,
+	0x4a, 0x04, 0x08, 0x12, 0x10, 0x13, 0x4a, 0x04, 0x08, 0x14, 0x10, 0x15, 0x22, 0x85, 0x01, 0x0a,
+	0x05, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x23, 0x0a, 0x0d, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69,
+	0x6c, 0x55, 0x72, 0x6c, 0x22, 0xf3, 0x02, 0x0a, 0x05, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x12, 0x31,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x35,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x29, 0x0a,
+	0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70,
+	0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x41, 0x6e, 0x67, 0x6c,
+	0x65, 0x52, 0x05, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x52, 0x08, 0x63, 0x61, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x52, 0x0a, 0x0c, 0x43, 0x61,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x5a,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x0b, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x5f, 0x61, 0x6e,
+	0x67, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x65, 0x78,
+	0x72, 0x73, 0x2e, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x52, 0x0a,
+	0x76, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x22, 0x45, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x22, 0x4d, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x22, 0x4d, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x2c, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
+	0xfd, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c,
+	0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72,
+	0x73, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x69,
+	0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x34, 0x0a, 0x0b, 0x76, 0x69, 0x64, 0x65,
+	0x6f, 0x5f, 0x61, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x41, 0x6e, 0x67,
+	0x6c, 0x65, 0x52, 0x0a, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x3f,
+	0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22,
+	0x6f, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62,
+	0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x09, 0x65,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x8c, 0x02, 0x0a, 0x10, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x2c, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x52, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x22,
+	0x2e, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x57, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x78, 0x0a, 0x1d, 0x46, 0x69, 0x6e, 0x64,
+	0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e,
+	0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69,
+	0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x70, 0x0a, 0x0e, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x2e, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73,
+	0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x73, 0x22, 0x78, 0x0a, 0x1e, 0x46, 0x69, 0x6e, 0x64, 0x44, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e,
+	0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5d,
+	0x0a, 0x15, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x72, 0x76, 0x69,
+	0x76, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x75,
+	0x72, 0x76, 0x69, 0x76, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x49, 0x64, 0x73, 0x22, 0xc9, 0x01,
+	0x0a, 0x19, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6d,
+	0x75, 0x73, 0x63, 0x6c, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x6d, 0x75, 0x73, 0x63, 0x6c, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x32,
+	0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x44, 0x69, 0x66, 0x66,
+	0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c,
+	0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x6e, 0x0a, 0x0e, 0x52, 0x65, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x08, 0x65,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52,
+	0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x22, 0x5e, 0x0a, 0x1a, 0x52, 0x65, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x54, 0x61, 0x78,
+	0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73,
+	0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x44, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e,
+	0x6f, 0x6d, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x47, 0x0a,
+	0x19, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72,
+	0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x74, 0x65,
+	0x72, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78,
+	0x72, 0x73, 0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52,
+	0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x22, 0x45, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e,
+	0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x22, 0x59, 0x0a,
+	0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54,
+	0x65, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72,
+	0x73, 0x2e, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a, 0x53, 0x0a, 0x0d, 0x56, 0x69, 0x64, 0x65,
+	0x6f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x1a, 0x56, 0x49, 0x44,
+	0x45, 0x4f, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x59, 0x4f, 0x55,
+	0x54, 0x55, 0x42, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x49, 0x4d, 0x45, 0x4f, 0x10,
+	0x02, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x4f, 0x53, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x58, 0x0a,
+	0x0b, 0x43, 0x61, 0x6d, 0x65, 0x72, 0x61, 0x41, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x18,
+	0x43, 0x41, 0x4d, 0x45, 0x52, 0x41, 0x5f, 0x41, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x52,
+	0x4f, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x49, 0x44, 0x45, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x4f, 0x56, 0x45,
+	0x52, 0x48, 0x45, 0x41, 0x44, 0x10, 0x04, 0x2a, 0x49, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12,
+	0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4e, 0x41, 0x45, 0x52, 0x4f, 0x42,
+	0x49, 0x43, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x45, 0x52, 0x4f, 0x42, 0x49, 0x43, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x4c, 0x45, 0x58, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59,
+	0x10, 0x03, 0x2a, 0x56, 0x0a, 0x0a, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79,
+	0x12, 0x1a, 0x0a, 0x16, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
+	0x42, 0x45, 0x47, 0x49, 0x4e, 0x4e, 0x45, 0x52, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e,
+	0x54, 0x45, 0x52, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08,
+	0x41, 0x44, 0x56, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x43, 0x0a, 0x09, 0x4d, 0x65,
+	0x63, 0x68, 0x61, 0x6e, 0x69, 0x63, 0x73, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x45, 0x43, 0x48, 0x41,
+	0x4e, 0x49, 0x43, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x2a,
+	0x3e, 0x0a, 0x05, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x4f, 0x52, 0x43,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x50, 0x55, 0x53, 0x48, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x55, 0x4c,
+	0x4c, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x41, 0x54, 0x49, 0x43, 0x10, 0x03, 0x2a,
+	0x55, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x1d, 0x0a, 0x19, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
+	0x43, 0x41, 0x53, 0x43, 0x41, 0x44, 0x45, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x55, 0x4c,
+	0x4c, 0x49, 0x46, 0x59, 0x10, 0x03, 0x2a, 0x60, 0x0a, 0x0e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x1b, 0x52, 0x45, 0x56, 0x49,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45,
+	0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10,
+	0x02, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a,
+	0x05, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x10, 0x04, 0x2a, 0x4d, 0x0a, 0x0c, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x54, 0x41, 0x58, 0x4f,
+	0x4e, 0x4f, 0x4d, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41, 0x54, 0x45, 0x47,
+	0x4f, 0x52, 0x59, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x55, 0x53, 0x43, 0x4c, 0x45, 0x5f,
+	0x47, 0x52, 0x4f, 0x55, 0x50, 0x10, 0x02, 0x32, 0xb8, 0x0a, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e,
+	0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14,
+	0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x62, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a,
+	0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x65,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x08, 0x65, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x32, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63,
+	0x69, 0x73, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x5b, 0x0a, 0x0e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x15, 0x2e, 0x70, 0x62,
+	0x65, 0x78, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x14, 0x2a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x63, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x76,
+	0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x8a, 0x01, 0x0a, 0x15,
+	0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x62,
+	0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31, 0x2f,
+	0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x8d, 0x01, 0x0a, 0x16, 0x46, 0x69, 0x6e,
+	0x64, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x46, 0x69, 0x6e,
+	0x64, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31, 0x2f,
+	0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x3a, 0x66, 0x69, 0x6e, 0x64, 0x44, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x61, 0x0a, 0x0e, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x65, 0x78,
+	0x72, 0x73, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x22, 0x1e, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x18, 0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x73, 0x3a, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x7c, 0x0a, 0x12, 0x52,
+	0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x12, 0x21, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19,
+	0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x3a,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x12, 0x6e, 0x0a, 0x11, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x12, 0x20,
+	0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31,
+	0x2f, 0x74, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x12, 0x69, 0x0a, 0x12, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x12,
+	0x21, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2e, 0x54, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14,
+	0x3a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x78, 0x6f,
+	0x6e, 0x6f, 0x6d, 0x79, 0x12, 0x6c, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
+	0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x21, 0x2e, 0x70, 0x62, 0x65,
+	0x78, 0x72, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f,
+	0x6d, 0x79, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x2a, 0x13, 0x2f,
+	0x76, 0x31, 0x2f, 0x74, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x2f, 0x7b, 0x6e, 0x61, 0x6d,
+	0x65, 0x7d, 0x42, 0x0b, 0x5a, 0x09, 0x70, 0x62, 0x65, 0x78, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_exercise_service_proto_rawDescOnce sync.Once
+	file_v1_exercise_service_proto_rawDescData = file_v1_exercise_service_proto_rawDesc
+)
+
+func file_v1_exercise_service_proto_rawDescGZIP() []byte {
+	file_v1_exercise_service_proto_rawDescOnce.Do(func() {
+		file_v1_exercise_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_exercise_service_proto_rawDescData)
+	})
+	return file_v1_exercise_service_proto_rawDescData
+}
+
+var file_v1_exercise_service_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_v1_exercise_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_v1_exercise_service_proto_goTypes = []interface{}{
+	(VideoProvider)(0),                     // 0: pbexrs.VideoProvider
+	(CameraAngle)(0),                       // 1: pbexrs.CameraAngle
+	(Kind)(0),                              // 2: pbexrs.Kind
+	(Difficulty)(0),                        // 3: pbexrs.Difficulty
+	(Mechanics)(0),                         // 4: pbexrs.Mechanics
+	(Force)(0),                             // 5: pbexrs.Force
+	(DeletePolicy)(0),                      // 6: pbexrs.DeletePolicy
+	(RevisionAction)(0),                    // 7: pbexrs.RevisionAction
+	(TaxonomyType)(0),                      // 8: pbexrs.TaxonomyType
+	(*Exercise)(nil),                       // 9: pbexrs.Exercise
+	(*Asset)(nil),                          // 10: pbexrs.Asset
+	(*Video)(nil),                          // 11: pbexrs.Video
+	(*CaptionTrack)(nil),                   // 12: pbexrs.CaptionTrack
+	(*GetExerciseRequest)(nil),             // 13: pbexrs.GetExerciseRequest
+	(*CreateExerciseRequest)(nil),          // 14: pbexrs.CreateExerciseRequest
+	(*UpdateRequest)(nil),                  // 15: pbexrs.UpdateRequest
+	(*DeleteRequest)(nil),                  // 16: pbexrs.DeleteRequest
+	(*ListExercisesRequest)(nil),           // 17: pbexrs.ListExercisesRequest
+	(*ListExercisesResponse)(nil),          // 18: pbexrs.ListExercisesResponse
+	(*ExerciseRevision)(nil),               // 19: pbexrs.ExerciseRevision
+	(*ListExerciseRevisionsRequest)(nil),   // 20: pbexrs.ListExerciseRevisionsRequest
+	(*ListExerciseRevisionsResponse)(nil),  // 21: pbexrs.ListExerciseRevisionsResponse
+	(*FindDuplicateExercisesRequest)(nil),  // 22: pbexrs.FindDuplicateExercisesRequest
+	(*DuplicateGroup)(nil),                 // 23: pbexrs.DuplicateGroup
+	(*FindDuplicateExercisesResponse)(nil), // 24: pbexrs.FindDuplicateExercisesResponse
+	(*MergeExercisesRequest)(nil),          // 25: pbexrs.MergeExercisesRequest
+	(*RecommendExercisesRequest)(nil),      // 26: pbexrs.RecommendExercisesRequest
+	(*Recommendation)(nil),                 // 27: pbexrs.Recommendation
+	(*RecommendExercisesResponse)(nil),     // 28: pbexrs.RecommendExercisesResponse
+	(*TaxonomyTerm)(nil),                   // 29: pbexrs.TaxonomyTerm
+	(*ListTaxonomyTermsRequest)(nil),       // 30: pbexrs.ListTaxonomyTermsRequest
+	(*ListTaxonomyTermsResponse)(nil),      // 31: pbexrs.ListTaxonomyTermsResponse
+	(*CreateTaxonomyTermRequest)(nil),      // 32: pbexrs.CreateTaxonomyTermRequest
+	(*DeleteTaxonomyTermRequest)(nil),      // 33: pbexrs.DeleteTaxonomyTermRequest
+	(*timestamp.Timestamp)(nil),            // 34: google.protobuf.Timestamp
+	(*duration.Duration)(nil),              // 35: google.protobuf.Duration
+	(*empty.Empty)(nil),                    // 36: google.protobuf.Empty
+}
+var file_v1_exercise_service_proto_depIdxs = []int32{
+	2,  // 0: pbexrs.Exercise.kind:type_name -> pbexrs.Kind
+	3,  // 1: pbexrs.Exercise.difficulty:type_name -> pbexrs.Difficulty
+	4,  // 2: pbexrs.Exercise.mechanics:type_name -> pbexrs.Mechanics
+	5,  // 3: pbexrs.Exercise.force:type_name -> pbexrs.Force
+	10, // 4: pbexrs.Exercise.image_assets:type_name -> pbexrs.Asset
+	11, // 5: pbexrs.Exercise.videos:type_name -> pbexrs.Video
+	34, // 6: pbexrs.Exercise.create_time:type_name -> google.protobuf.Timestamp
+	34, // 7: pbexrs.Exercise.update_time:type_name -> google.protobuf.Timestamp
+	0,  // 8: pbexrs.Video.provider:type_name -> pbexrs.VideoProvider
+	35, // 9: pbexrs.Video.duration:type_name -> google.protobuf.Duration
+	35, // 10: pbexrs.Video.start_offset:type_name -> google.protobuf.Duration
+	35, // 11: pbexrs.Video.end_offset:type_name -> google.protobuf.Duration
+	1,  // 12: pbexrs.Video.angle:type_name -> pbexrs.CameraAngle
+	12, // 13: pbexrs.Video.captions:type_name -> pbexrs.CaptionTrack
+	1,  // 14: pbexrs.GetExerciseRequest.video_angle:type_name -> pbexrs.CameraAngle
+	9,  // 15: pbexrs.CreateExerciseRequest.exercise:type_name -> pbexrs.Exercise
+	9,  // 16: pbexrs.UpdateRequest.exercise:type_name -> pbexrs.Exercise
+	6,  // 17: pbexrs.DeleteRequest.policy:type_name -> pbexrs.DeletePolicy
+	3,  // 18: pbexrs.ListExercisesRequest.difficulty:type_name -> pbexrs.Difficulty
+	1,  // 19: pbexrs.ListExercisesRequest.video_angle:type_name -> pbexrs.CameraAngle
+	34, // 20: pbexrs.ListExercisesRequest.updated_after:type_name -> google.protobuf.Timestamp
+	9,  // 21: pbexrs.ListExercisesResponse.exercises:type_name -> pbexrs.Exercise
+	7,  // 22: pbexrs.ExerciseRevision.action:type_name -> pbexrs.RevisionAction
+	34, // 23: pbexrs.ExerciseRevision.time:type_name -> google.protobuf.Timestamp
+	9,  // 24: pbexrs.ExerciseRevision.exercise:type_name -> pbexrs.Exercise
+	19, // 25: pbexrs.ListExerciseRevisionsResponse.revisions:type_name -> pbexrs.ExerciseRevision
+	9,  // 26: pbexrs.DuplicateGroup.exercises:type_name -> pbexrs.Exercise
+	23, // 27: pbexrs.FindDuplicateExercisesResponse.groups:type_name -> pbexrs.DuplicateGroup
+	3,  // 28: pbexrs.RecommendExercisesRequest.difficulty:type_name -> pbexrs.Difficulty
+	9,  // 29: pbexrs.Recommendation.exercise:type_name -> pbexrs.Exercise
+	27, // 30: pbexrs.RecommendExercisesResponse.recommendations:type_name -> pbexrs.Recommendation
+	8,  // 31: pbexrs.TaxonomyTerm.type:type_name -> pbexrs.TaxonomyType
+	8,  // 32: pbexrs.ListTaxonomyTermsRequest.type:type_name -> pbexrs.TaxonomyType
+	29, // 33: pbexrs.ListTaxonomyTermsResponse.terms:type_name -> pbexrs.TaxonomyTerm
+	29, // 34: pbexrs.CreateTaxonomyTermRequest.term:type_name -> pbexrs.TaxonomyTerm
+	8,  // 35: pbexrs.DeleteTaxonomyTermRequest.type:type_name -> pbexrs.TaxonomyType
+	13, // 36: pbexrs.ExerciseService.GetExercise:input_type -> pbexrs.GetExerciseRequest
+	14, // 37: pbexrs.ExerciseService.CreateExercise:input_type -> pbexrs.CreateExerciseRequest
+	15, // 38: pbexrs.ExerciseService.UpdateExercise:input_type -> pbexrs.UpdateRequest
+	16, // 39: pbexrs.ExerciseService.DeleteExercise:input_type -> pbexrs.DeleteRequest
+	17, // 40: pbexrs.ExerciseService.ListExercises:input_type -> pbexrs.ListExercisesRequest
+	20, // 41: pbexrs.ExerciseService.ListExerciseRevisions:input_type -> pbexrs.ListExerciseRevisionsRequest
+	22, // 42: pbexrs.ExerciseService.FindDuplicateExercises:input_type -> pbexrs.FindDuplicateExercisesRequest
+	25, // 43: pbexrs.ExerciseService.MergeExercises:input_type -> pbexrs.MergeExercisesRequest
+	26, // 44: pbexrs.ExerciseService.RecommendExercises:input_type -> pbexrs.RecommendExercisesRequest
+	30, // 45: pbexrs.ExerciseService.ListTaxonomyTerms:input_type -> pbexrs.ListTaxonomyTermsRequest
+	32, // 46: pbexrs.ExerciseService.CreateTaxonomyTerm:input_type -> pbexrs.CreateTaxonomyTermRequest
+	33, // 47: pbexrs.ExerciseService.DeleteTaxonomyTerm:input_type -> pbexrs.DeleteTaxonomyTermRequest
+	9,  // 48: pbexrs.ExerciseService.GetExercise:output_type -> pbexrs.Exercise
+	9,  // 49: pbexrs.ExerciseService.CreateExercise:output_type -> pbexrs.Exercise
+	9,  // 50: pbexrs.ExerciseService.UpdateExercise:output_type -> pbexrs.Exercise
+	36, // 51: pbexrs.ExerciseService.DeleteExercise:output_type -> google.protobuf.Empty
+	18, // 52: pbexrs.ExerciseService.ListExercises:output_type -> pbexrs.ListExercisesResponse
+	21, // 53: pbexrs.ExerciseService.ListExerciseRevisions:output_type -> pbexrs.ListExerciseRevisionsResponse
+	24, // 54: pbexrs.ExerciseService.FindDuplicateExercises:output_type -> pbexrs.FindDuplicateExercisesResponse
+	9,  // 55: pbexrs.ExerciseService.MergeExercises:output_type -> pbexrs.Exercise
+	28, // 56: pbexrs.ExerciseService.RecommendExercises:output_type -> pbexrs.RecommendExercisesResponse
+	31, // 57: pbexrs.ExerciseService.ListTaxonomyTerms:output_type -> pbexrs.ListTaxonomyTermsResponse
+	29, // 58: pbexrs.ExerciseService.CreateTaxonomyTerm:output_type -> pbexrs.TaxonomyTerm
+	36, // 59: pbexrs.ExerciseService.DeleteTaxonomyTerm:output_type -> google.protobuf.Empty
+	48, // [48:60] is the sub-list for method output_type
+	36, // [36:48] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_v1_exercise_service_proto_init() }
+func file_v1_exercise_service_proto_init() {
+	if File_v1_exercise_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_exercise_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Exercise); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Asset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Video); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptionTrack); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExerciseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateExerciseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExercisesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExercisesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExerciseRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExerciseRevisionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExerciseRevisionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindDuplicateExercisesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DuplicateGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindDuplicateExercisesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeExercisesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -596,8 +2933,8 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetExerciseRequest); i {
+		file_v1_exercise_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecommendExercisesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -608,8 +2945,8 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateExerciseRequest); i {
+		file_v1_exercise_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recommendation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -620,8 +2957,8 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRequest); i {
+		file_v1_exercise_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecommendExercisesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -632,8 +2969,8 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteRequest); i {
+		file_v1_exercise_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaxonomyTerm); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -644,8 +2981,8 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListExercisesRequest); i {
+		file_v1_exercise_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTaxonomyTermsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -656,8 +2993,32 @@ func file_v1_exercise_service_proto_init() {
 				return nil
 			}
 		}
-		file_v1_exercise_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListExercisesResponse); i {
+		file_v1_exercise_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTaxonomyTermsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTaxonomyTermRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_exercise_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTaxonomyTermRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -674,13 +3035,14 @@ func file_v1_exercise_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_exercise_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      9,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_exercise_service_proto_goTypes,
 		DependencyIndexes: file_v1_exercise_service_proto_depIdxs,
+		EnumInfos:         file_v1_exercise_service_proto_enumTypes,
 		MessageInfos:      file_v1_exercise_service_proto_msgTypes,
 	}.Build()
 	File_v1_exercise_service_proto = out.File
@@ -707,6 +3069,18 @@ type ExerciseServiceClient interface {
 	UpdateExercise(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Exercise, error)
 	DeleteExercise(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	ListExercises(ctx context.Context, in *ListExercisesRequest, opts ...grpc.CallOption) (*ListExercisesResponse, error)
+	//History of the changes of an exercise, oldest first
+	ListExerciseRevisions(ctx context.Context, in *ListExerciseRevisionsRequest, opts ...grpc.CallOption) (*ListExerciseRevisionsResponse, error)
+	//Groups of exercises that are likely the same, like "Push Up" and "Pushups"
+	FindDuplicateExercises(ctx context.Context, in *FindDuplicateExercisesRequest, opts ...grpc.CallOption) (*FindDuplicateExercisesResponse, error)
+	//Merges duplicates into a survivor, deleting them once their references point to it
+	MergeExercises(ctx context.Context, in *MergeExercisesRequest, opts ...grpc.CallOption) (*Exercise, error)
+	//Exercises working the muscle groups asked for with the equipment at hand, explaining every pick
+	RecommendExercises(ctx context.Context, in *RecommendExercisesRequest, opts ...grpc.CallOption) (*RecommendExercisesResponse, error)
+	//Taxonomy of allowed categories and muscle groups
+	ListTaxonomyTerms(ctx context.Context, in *ListTaxonomyTermsRequest, opts ...grpc.CallOption) (*ListTaxonomyTermsResponse, error)
+	CreateTaxonomyTerm(ctx context.Context, in *CreateTaxonomyTermRequest, opts ...grpc.CallOption) (*TaxonomyTerm, error)
+	DeleteTaxonomyTerm(ctx context.Context, in *DeleteTaxonomyTermRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type exerciseServiceClient struct {
@@ -762,6 +3136,69 @@ func (c *exerciseServiceClient) ListExercises(ctx context.Context, in *ListExerc
 	return out, nil
 }
 
+func (c *exerciseServiceClient) ListExerciseRevisions(ctx context.Context, in *ListExerciseRevisionsRequest, opts ...grpc.CallOption) (*ListExerciseRevisionsResponse, error) {
+	out := new(ListExerciseRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/ListExerciseRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) FindDuplicateExercises(ctx context.Context, in *FindDuplicateExercisesRequest, opts ...grpc.CallOption) (*FindDuplicateExercisesResponse, error) {
+	out := new(FindDuplicateExercisesResponse)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/FindDuplicateExercises", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) MergeExercises(ctx context.Context, in *MergeExercisesRequest, opts ...grpc.CallOption) (*Exercise, error) {
+	out := new(Exercise)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/MergeExercises", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) RecommendExercises(ctx context.Context, in *RecommendExercisesRequest, opts ...grpc.CallOption) (*RecommendExercisesResponse, error) {
+	out := new(RecommendExercisesResponse)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/RecommendExercises", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) ListTaxonomyTerms(ctx context.Context, in *ListTaxonomyTermsRequest, opts ...grpc.CallOption) (*ListTaxonomyTermsResponse, error) {
+	out := new(ListTaxonomyTermsResponse)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/ListTaxonomyTerms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) CreateTaxonomyTerm(ctx context.Context, in *CreateTaxonomyTermRequest, opts ...grpc.CallOption) (*TaxonomyTerm, error) {
+	out := new(TaxonomyTerm)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/CreateTaxonomyTerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exerciseServiceClient) DeleteTaxonomyTerm(ctx context.Context, in *DeleteTaxonomyTermRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pbexrs.ExerciseService/DeleteTaxonomyTerm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExerciseServiceServer is the server API for ExerciseService service.
 type ExerciseServiceServer interface {
 	GetExercise(context.Context, *GetExerciseRequest) (*Exercise, error)
@@ -770,6 +3207,18 @@ type ExerciseServiceServer interface {
 	UpdateExercise(context.Context, *UpdateRequest) (*Exercise, error)
 	DeleteExercise(context.Context, *DeleteRequest) (*empty.Empty, error)
 	ListExercises(context.Context, *ListExercisesRequest) (*ListExercisesResponse, error)
+	//History of the changes of an exercise, oldest first
+	ListExerciseRevisions(context.Context, *ListExerciseRevisionsRequest) (*ListExerciseRevisionsResponse, error)
+	//Groups of exercises that are likely the same, like "Push Up" and "Pushups"
+	FindDuplicateExercises(context.Context, *FindDuplicateExercisesRequest) (*FindDuplicateExercisesResponse, error)
+	//Merges duplicates into a survivor, deleting them once their references point to it
+	MergeExercises(context.Context, *MergeExercisesRequest) (*Exercise, error)
+	//Exercises working the muscle groups asked for with the equipment at hand, explaining every pick
+	RecommendExercises(context.Context, *RecommendExercisesRequest) (*RecommendExercisesResponse, error)
+	//Taxonomy of allowed categories and muscle groups
+	ListTaxonomyTerms(context.Context, *ListTaxonomyTermsRequest) (*ListTaxonomyTermsResponse, error)
+	CreateTaxonomyTerm(context.Context, *CreateTaxonomyTermRequest) (*TaxonomyTerm, error)
+	DeleteTaxonomyTerm(context.Context, *DeleteTaxonomyTermRequest) (*empty.Empty, error)
 }
 
 // UnimplementedExerciseServiceServer can be embedded to have forward compatible implementations.
@@ -791,6 +3240,27 @@ func (*UnimplementedExerciseServiceServer) DeleteExercise(context.Context, *Dele
 func (*UnimplementedExerciseServiceServer) ListExercises(context.Context, *ListExercisesRequest) (*ListExercisesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListExercises not implemented")
 }
+func (*UnimplementedExerciseServiceServer) ListExerciseRevisions(context.Context, *ListExerciseRevisionsRequest) (*ListExerciseRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExerciseRevisions not implemented")
+}
+func (*UnimplementedExerciseServiceServer) FindDuplicateExercises(context.Context, *FindDuplicateExercisesRequest) (*FindDuplicateExercisesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDuplicateExercises not implemented")
+}
+func (*UnimplementedExerciseServiceServer) MergeExercises(context.Context, *MergeExercisesRequest) (*Exercise, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeExercises not implemented")
+}
+func (*UnimplementedExerciseServiceServer) RecommendExercises(context.Context, *RecommendExercisesRequest) (*RecommendExercisesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendExercises not implemented")
+}
+func (*UnimplementedExerciseServiceServer) ListTaxonomyTerms(context.Context, *ListTaxonomyTermsRequest) (*ListTaxonomyTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaxonomyTerms not implemented")
+}
+func (*UnimplementedExerciseServiceServer) CreateTaxonomyTerm(context.Context, *CreateTaxonomyTermRequest) (*TaxonomyTerm, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTaxonomyTerm not implemented")
+}
+func (*UnimplementedExerciseServiceServer) DeleteTaxonomyTerm(context.Context, *DeleteTaxonomyTermRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaxonomyTerm not implemented")
+}
 
 func RegisterExerciseServiceServer(s *grpc.Server, srv ExerciseServiceServer) {
 	s.RegisterService(&_ExerciseService_serviceDesc, srv)
@@ -886,6 +3356,132 @@ func _ExerciseService_ListExercises_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExerciseService_ListExerciseRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExerciseRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).ListExerciseRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/ListExerciseRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).ListExerciseRevisions(ctx, req.(*ListExerciseRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_FindDuplicateExercises_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicateExercisesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).FindDuplicateExercises(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/FindDuplicateExercises",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).FindDuplicateExercises(ctx, req.(*FindDuplicateExercisesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_MergeExercises_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeExercisesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).MergeExercises(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/MergeExercises",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).MergeExercises(ctx, req.(*MergeExercisesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_RecommendExercises_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendExercisesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).RecommendExercises(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/RecommendExercises",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).RecommendExercises(ctx, req.(*RecommendExercisesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_ListTaxonomyTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaxonomyTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).ListTaxonomyTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/ListTaxonomyTerms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).ListTaxonomyTerms(ctx, req.(*ListTaxonomyTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_CreateTaxonomyTerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTaxonomyTermRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).CreateTaxonomyTerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/CreateTaxonomyTerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).CreateTaxonomyTerm(ctx, req.(*CreateTaxonomyTermRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExerciseService_DeleteTaxonomyTerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaxonomyTermRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExerciseServiceServer).DeleteTaxonomyTerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbexrs.ExerciseService/DeleteTaxonomyTerm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExerciseServiceServer).DeleteTaxonomyTerm(ctx, req.(*DeleteTaxonomyTermRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ExerciseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pbexrs.ExerciseService",
 	HandlerType: (*ExerciseServiceServer)(nil),
@@ -910,6 +3506,34 @@ var _ExerciseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListExercises",
 			Handler:    _ExerciseService_ListExercises_Handler,
 		},
+		{
+			MethodName: "ListExerciseRevisions",
+			Handler:    _ExerciseService_ListExerciseRevisions_Handler,
+		},
+		{
+			MethodName: "FindDuplicateExercises",
+			Handler:    _ExerciseService_FindDuplicateExercises_Handler,
+		},
+		{
+			MethodName: "MergeExercises",
+			Handler:    _ExerciseService_MergeExercises_Handler,
+		},
+		{
+			MethodName: "RecommendExercises",
+			Handler:    _ExerciseService_RecommendExercises_Handler,
+		},
+		{
+			MethodName: "ListTaxonomyTerms",
+			Handler:    _ExerciseService_ListTaxonomyTerms_Handler,
+		},
+		{
+			MethodName: "CreateTaxonomyTerm",
+			Handler:    _ExerciseService_CreateTaxonomyTerm_Handler,
+		},
+		{
+			MethodName: "DeleteTaxonomyTerm",
+			Handler:    _ExerciseService_DeleteTaxonomyTerm_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/exercise_service.proto",
@@ -0,0 +1,16 @@
+package v1
+
+import (
+	//embeds the spec
+	_ "embed"
+)
+
+//go:generate protoc -I.. -I../third_party --go_out=plugins=grpc,paths=source_relative:.. ../v1/exercise_service.proto
+//go:generate protoc -I.. -I../third_party --grpc-gateway_out=logtostderr=true,paths=source_relative:.. ../v1/exercise_service.proto
+//go:generate protoc -I.. -I../third_party --swagger_out=logtostderr=true:.. ../v1/exercise_service.proto
+
+//OpenAPI is the swagger spec of the gateway. It is generated with the code above, so running
+//go generate keeps the served spec in line with the proto
+//
+//go:embed exercise_service.swagger.json
+var OpenAPI []byte